@@ -0,0 +1,124 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a filter in the Bitbucket query language, sent as the q parameter of list endpoints so
+// bitbucket only returns what matches instead of every page of the collection. Build one with the
+// comparisons like Eq and combine them with And and Or, values are quoted and escaped as BBQL wants them.
+type Query struct {
+	expr string
+}
+
+// String is the query as bitbucket reads it, like `name = "illusions" AND is_private = true`
+func (q Query) String() string {
+	return q.expr
+}
+
+// IsZero reports whether the query has no condition and matches everything
+func (q Query) IsZero() bool {
+	return q.expr == ""
+}
+
+func compare(field, operator string, value interface{}) Query {
+	return Query{expr: fmt.Sprintf("%s %s %s", field, operator, bbqlValue(value))}
+}
+
+// Eq matches objects whose field equals value
+func Eq(field string, value interface{}) Query { return compare(field, "=", value) }
+
+// Ne matches objects whose field doesn't equal value
+func Ne(field string, value interface{}) Query { return compare(field, "!=", value) }
+
+// Contains matches objects whose field contains value, case insensitive
+func Contains(field, value string) Query { return compare(field, "~", value) }
+
+// Gt matches objects whose field is greater than value
+func Gt(field string, value interface{}) Query { return compare(field, ">", value) }
+
+// Gte matches objects whose field is greater than or equal to value
+func Gte(field string, value interface{}) Query { return compare(field, ">=", value) }
+
+// Lt matches objects whose field is less than value
+func Lt(field string, value interface{}) Query { return compare(field, "<", value) }
+
+// Lte matches objects whose field is less than or equal to value
+func Lte(field string, value interface{}) Query { return compare(field, "<=", value) }
+
+// And matches objects matching every one of queries
+func And(queries ...Query) Query { return combine("AND", queries) }
+
+// Or matches objects matching any of queries
+func Or(queries ...Query) Query { return combine("OR", queries) }
+
+// combine joins the queries with operator, each in parentheses so nesting And and Or keeps its meaning.
+// Empty queries are left out.
+func combine(operator string, queries []Query) Query {
+	var exprs []string
+	for _, q := range queries {
+		if !q.IsZero() {
+			exprs = append(exprs, q.expr)
+		}
+	}
+
+	switch len(exprs) {
+	case 0:
+		return Query{}
+	case 1:
+		return Query{expr: exprs[0]}
+	}
+
+	for i, expr := range exprs {
+		exprs[i] = "(" + expr + ")"
+	}
+	return Query{expr: strings.Join(exprs, " "+operator+" ")}
+}
+
+// bbqlQuote wraps s in double quotes. BBQL only knows the escapes for backslashes and double quotes,
+// everything else is sent as it is.
+func bbqlQuote(s string) string {
+	return `"` + bbqlEscaper.Replace(s) + `"`
+}
+
+var bbqlEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// bbqlValue renders value as a BBQL literal. Strings are quoted, times are sent as ISO 8601 dates.
+func bbqlValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return bbqlQuote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.UTC().Format("2006-01-02T15:04:05Z")
+	default:
+		return bbqlQuote(fmt.Sprint(v))
+	}
+}
+
+// WithQuery adds q as the q parameter to an endpoint, an empty query leaves the endpoint as it is
+func WithQuery(endpoint string, q Query) string {
+	if q.IsZero() {
+		return endpoint
+	}
+
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	return endpoint + separator + "q=" + url.QueryEscape(q.expr)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	cases := []struct {
+		query    Query
+		expected string
+	}{
+		{Eq("name", "illusions"), `name = "illusions"`},
+		{Eq("name", `the "final" countdown`), `name = "the \"final\" countdown"`},
+		{Eq("path", `C:\magic`), `path = "C:\\magic"`},
+		{Eq("name", "Gob Bluth é\a"), "name = \"Gob Bluth é\a\""},
+		{Ne("is_private", true), `is_private != true`},
+		{Contains("description", "magic"), `description ~ "magic"`},
+		{Gte("size", 1024), `size >= 1024`},
+		{Lt("updated_on", time.Date(2020, 1, 23, 10, 0, 0, 0, time.UTC)), `updated_on < 2020-01-23T10:00:00Z`},
+		{Eq("parent", nil), `parent = null`},
+		{And(Eq("project.key", "MAGIC"), Or(Eq("language", "go"), Eq("language", "rust"))), `(project.key = "MAGIC") AND ((language = "go") OR (language = "rust"))`},
+		{And(Query{}, Eq("slug", "illusions")), `slug = "illusions"`},
+		{Or(), ``},
+	}
+
+	for _, tc := range cases {
+		if tc.query.String() != tc.expected {
+			t.Fatalf("expected %s, got %s", tc.expected, tc.query)
+		}
+	}
+}
+
+func TestWithQuery(t *testing.T) {
+	endpoint := WithQuery("2.0/repositories/gob?pagelen=100", And(Eq("name", "illusions & magic"), Eq("is_private", true)))
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if q := parsed.Query().Get("q"); q != `(name = "illusions & magic") AND (is_private = true)` {
+		t.Fatalf("expected the query to survive encoding, got %q", q)
+	}
+
+	if parsed.Query().Get("pagelen") != "100" {
+		t.Fatalf("expected the other parameters to be kept, got %s", endpoint)
+	}
+
+	if WithQuery("2.0/repositories/gob", Query{}) != "2.0/repositories/gob" {
+		t.Fatalf("expected an empty query to leave the endpoint alone")
+	}
+}
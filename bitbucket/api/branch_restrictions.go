@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+)
+
+// BranchRestrictionsService talks to the branch restriction endpoints of a repository.
+type BranchRestrictionsService service
+
+// BranchRestriction is the data we need to send to create a new branch restriction for the repository
+type BranchRestriction struct {
+	ID              int     `json:"id,omitempty"`
+	Kind            string  `json:"kind,omitempty"`
+	BranchMatchKind string  `json:"branch_match_kind,omitempty"`
+	BranchType      string  `json:"branch_type,omitempty"`
+	Pattern         string  `json:"pattern"`
+	Value           int     `json:"value,omitempty"`
+	Users           []User  `json:"users"`
+	Groups          []Group `json:"groups"`
+}
+
+// Group is the group we want to add to a branch restriction
+type Group struct {
+	Slug  string `json:"slug,omitempty"`
+	Owner User   `json:"owner,omitempty"`
+}
+
+func branchRestrictionEndpoint(owner, slug, id string) string {
+	return apiPath("2.0/repositories/%s/%s/branch-restrictions/%s", owner, slug, id)
+}
+
+// Get fetches the branch restriction with the given id.
+func (s *BranchRestrictionsService) Get(ctx context.Context, owner, slug, id string) (*BranchRestriction, error) {
+	var restriction BranchRestriction
+	_, err := s.client.doJSON(ctx, "GET", branchRestrictionEndpoint(owner, slug, id), nil, &restriction)
+	if err != nil {
+		return nil, err
+	}
+	return &restriction, nil
+}
+
+// Create adds a branch restriction to a repository.
+func (s *BranchRestrictionsService) Create(ctx context.Context, owner, slug string, restriction *BranchRestriction) (*BranchRestriction, error) {
+	var created BranchRestriction
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/branch-restrictions", owner, slug), restriction, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the branch restriction with the given id.
+func (s *BranchRestrictionsService) Update(ctx context.Context, owner, slug, id string, restriction *BranchRestriction) (*BranchRestriction, error) {
+	var updated BranchRestriction
+	_, err := s.client.doJSON(ctx, "PUT", branchRestrictionEndpoint(owner, slug, id), restriction, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the branch restriction with the given id.
+func (s *BranchRestrictionsService) Delete(ctx context.Context, owner, slug, id string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", branchRestrictionEndpoint(owner, slug, id), nil, nil)
+	return err
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+)
+
+// BranchingModelService talks to the branching model settings of repositories and projects.
+type BranchingModelService service
+
+// BranchingModel is how the branches of a repository or project are named and which of them are the
+// development and production branch. DefaultBranchDeletion is whether merging a pull request deletes its
+// source branch unless its author unticks it.
+type BranchingModel struct {
+	Development           *BranchingModelBranch `json:"development,omitempty"`
+	Production            *BranchingModelBranch `json:"production,omitempty"`
+	BranchTypes           []BranchType          `json:"branch_types,omitempty"`
+	DefaultBranchDeletion *bool                 `json:"default_branch_deletion,omitempty"`
+}
+
+// BranchingModelBranch is the development or production branch of a branching model. Only production can
+// be disabled, IsValid tells whether the branch it names exists and is only ever set by bitbucket.
+type BranchingModelBranch struct {
+	Name          string `json:"name,omitempty"`
+	UseMainbranch bool   `json:"use_mainbranch"`
+	Enabled       *bool  `json:"enabled,omitempty"`
+	IsValid       bool   `json:"is_valid,omitempty"`
+}
+
+// BranchType is the prefix of the branches of one kind, like `feature/` for features
+type BranchType struct {
+	Kind    string `json:"kind"`
+	Enabled bool   `json:"enabled"`
+	Prefix  string `json:"prefix,omitempty"`
+}
+
+func repositoryBranchingModelEndpoint(owner, slug string) string {
+	return apiPath("2.0/repositories/%s/%s/branching-model/settings", owner, slug)
+}
+
+// Get fetches the branching model settings of a repository.
+func (s *BranchingModelService) Get(ctx context.Context, owner, slug string) (*BranchingModel, error) {
+	var model BranchingModel
+	_, err := s.client.doJSON(ctx, "GET", repositoryBranchingModelEndpoint(owner, slug), nil, &model)
+	if err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// Update changes the branching model settings of a repository, what model leaves out is kept.
+func (s *BranchingModelService) Update(ctx context.Context, owner, slug string, model *BranchingModel) (*BranchingModel, error) {
+	var updated BranchingModel
+	_, err := s.client.doJSON(ctx, "PUT", repositoryBranchingModelEndpoint(owner, slug), model, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func projectBranchingModelEndpoint(owner, key string) string {
+	return apiPath("2.0/workspaces/%s/projects/%s/branching-model/settings", owner, key)
+}
+
+// GetForProject fetches the branching model settings of a project, its repositories inherit them.
+func (s *BranchingModelService) GetForProject(ctx context.Context, owner, key string) (*BranchingModel, error) {
+	var model BranchingModel
+	_, err := s.client.doJSON(ctx, "GET", projectBranchingModelEndpoint(owner, key), nil, &model)
+	if err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// UpdateForProject changes the branching model settings of a project, what model leaves out is kept.
+func (s *BranchingModelService) UpdateForProject(ctx context.Context, owner, key string, model *BranchingModel) (*BranchingModel, error) {
+	var updated BranchingModel
+	_, err := s.client.doJSON(ctx, "PUT", projectBranchingModelEndpoint(owner, key), model, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
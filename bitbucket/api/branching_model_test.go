@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBranchingModelUpdateForProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/2.0/workspaces/gob/projects/MAGIC/branching-model/settings" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"production":{"use_mainbranch":false,"enabled":false},"branch_types":[{"kind":"feature","enabled":true,"prefix":"feat/"}]}` {
+			t.Fatalf("unexpected body %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"production":{"use_mainbranch":false,"enabled":false}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	disabled := false
+	model, err := client.BranchingModel.UpdateForProject(context.Background(), "gob", "MAGIC", &BranchingModel{
+		Production:  &BranchingModelBranch{Enabled: &disabled},
+		BranchTypes: []BranchType{{Kind: "feature", Enabled: true, Prefix: "feat/"}},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if model.Production == nil || model.Production.Enabled == nil || *model.Production.Enabled {
+		t.Fatalf("expected a disabled production branch, got %+v", model.Production)
+	}
+}
+
+func TestBranchingModelUpdateDefaultBranchDeletion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"default_branch_deletion":true}` {
+			t.Fatalf("unexpected body %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"default_branch_deletion":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	deletion := true
+	model, err := client.BranchingModel.Update(context.Background(), "gob", "illusions", &BranchingModel{DefaultBranchDeletion: &deletion})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if model.DefaultBranchDeletion == nil || !*model.DefaultBranchDeletion {
+		t.Fatalf("expected source branches to be deleted by default, got %+v", model)
+	}
+}
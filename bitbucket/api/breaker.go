@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// circuitBreaker stops sending requests to an endpoint that failed too many times in a row. Once it
+// opens for an endpoint it stays open until the client is thrown away, which is the end of the run, so a
+// broken endpoint fails every remaining resource right away instead of each of them retrying until its
+// timeout.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	endpoints map[string]*endpointFailures
+}
+
+type endpointFailures struct {
+	count int
+	last  string
+}
+
+// SetCircuitBreaker makes the client give up on an endpoint after threshold requests to it failed in a
+// row, zero never gives up.
+func (c *Client) SetCircuitBreaker(threshold int) {
+	c.breaker = nil
+	if threshold > 0 {
+		c.breaker = &circuitBreaker{threshold: threshold, endpoints: make(map[string]*endpointFailures)}
+	}
+}
+
+// breakerKey is the method and path of a request, the query only selects pages of the same endpoint
+func breakerKey(method, endpoint string) string {
+	if i := strings.Index(endpoint, "?"); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+	return method + " " + strings.TrimPrefix(endpoint, "/")
+}
+
+// allow fails when the endpoint failed too often already
+func (b *circuitBreaker) allow(method, endpoint string) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := breakerKey(method, endpoint)
+	if failures, ok := b.endpoints[key]; ok && failures.count >= b.threshold {
+		return fmt.Errorf("not sending %s: it failed %d times in a row, the last time with: %s", key, failures.count, failures.last)
+	}
+
+	return nil
+}
+
+// record counts a failed request against its endpoint, a successful one resets the count. Only failures
+// of bitbucket or the network count, not requests bitbucket rejected or that were cancelled.
+func (b *circuitBreaker) record(ctx context.Context, method, endpoint string, resp *http.Response, err error) {
+	if b == nil || ctx.Err() != nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := breakerKey(method, endpoint)
+
+	var reason string
+	switch {
+	case err != nil:
+		reason = err.Error()
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		reason = resp.Status
+	default:
+		delete(b.endpoints, key)
+		return
+	}
+
+	failures, ok := b.endpoints[key]
+	if !ok {
+		failures = &endpointFailures{}
+		b.endpoints[key] = failures
+	}
+	failures.count++
+	failures.last = reason
+}
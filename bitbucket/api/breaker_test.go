@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientCircuitBreaker(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if strings.HasSuffix(r.URL.Path, "/broken") {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	client.SetCircuitBreaker(2)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), "2.0/repositories/gob/broken"); err == nil {
+			t.Fatalf("expected the 503 to be an error")
+		}
+	}
+
+	_, err := client.Get(context.Background(), "2.0/repositories/gob/broken")
+	if err == nil || !strings.Contains(err.Error(), "failed 2 times in a row") {
+		t.Fatalf("expected the endpoint to be given up on, got %v", err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected no request once the endpoint was given up on, got %d", requests)
+	}
+
+	if _, err := client.Get(context.Background(), "2.0/repositories/gob/illusions"); err != nil {
+		t.Fatalf("expected other endpoints to still be requested, got %s", err)
+	}
+}
+
+func TestClientCircuitBreaker_resetsOnSuccess(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests%2 == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	client.SetCircuitBreaker(2)
+
+	for i := 0; i < 6; i++ {
+		client.Get(context.Background(), "2.0/user")
+	}
+
+	if requests != 6 {
+		t.Fatalf("expected failures between successes not to add up, got %d requests", requests)
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+)
+
+// etagCache remembers the ETag and body of GET responses so unchanged objects can be fetched again with
+// If-None-Match. Bitbucket answers those with an empty 304, which is served from the cache and is far
+// cheaper against the rate limits than sending the whole object again.
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	etag string
+	body []byte
+}
+
+func newETagCache() *etagCache {
+	return &etagCache{entries: make(map[string]cachedResponse)}
+}
+
+// prepare asks bitbucket to only send the object when it changed since it was cached
+func (c *etagCache) prepare(req *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[req.URL.String()]; ok {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+}
+
+// update keeps the cache in line with the response and returns the body to hand to the caller. A 304
+// is turned back into the 200 it stands for.
+func (c *etagCache) update(req *http.Request, resp *http.Response, body []byte) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := req.URL.String()
+	entry, ok := c.entries[key]
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK (cached)"
+		return entry.body
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "":
+		c.entries[key] = cachedResponse{etag: resp.Header.Get("ETag"), body: body}
+	default:
+		delete(c.entries, key)
+	}
+
+	return body
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientETagCache(t *testing.T) {
+	revalidated := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidated++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"slug": "illusions", "name": "Illusions"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	for i := 0; i < 2; i++ {
+		repo, err := client.Repositories.Get(context.Background(), "gob", "illusions")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if repo.Name != "Illusions" {
+			t.Fatalf("expected the repository to be decoded, got %+v", repo)
+		}
+	}
+
+	if revalidated != 1 {
+		t.Fatalf("expected the second request to be revalidated, got %d", revalidated)
+	}
+}
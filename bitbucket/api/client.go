@@ -0,0 +1,433 @@
+// Package api is a typed client for the parts of the bitbucket cloud api the provider manages. Every
+// request goes through Client, which takes care of auth, retries, rate limiting and logging, and the
+// services hanging off it build the endpoints and decode the responses.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// Auth methods the client can be configured with, used to tailor error messages.
+const (
+	AuthMethodPassword        string = "app password"
+	AuthMethodOAuthToken      string = "OAuth token"
+	AuthMethodOAuthClient     string = "OAuth client credentials"
+	AuthMethodWorkspaceToken  string = "workspace access token"
+	AuthMethodRepositoryToken string = "repository access token"
+	AuthMethodAPIToken        string = "Atlassian API token"
+	AuthMethodPipelineOIDC    string = "pipelines OIDC token"
+)
+
+const (
+	// BitbucketEndpoint is the fqdn used to talk to bitbucket
+	BitbucketEndpoint string = "https://api.bitbucket.org/"
+)
+
+// Client is the base internal Client to talk to bitbuckets API. This should be a username and password
+// the password should be a app-password. Atlassian API tokens are sent as basic auth with the Email
+// of the account instead of the username. When a TokenSource is set the requests are authenticated with
+// the bearer token it hands out instead. TokenRepository is the `owner/slug` a repository access token
+// belongs to, requests for any other repository are refused before they are sent. BaseURL can point the
+// client at a gateway in front of bitbucket and defaults to BitbucketEndpoint. Workspace is used for
+// resources that don't set their own owner. Use NewClient to get a Client with its services set up.
+type Client struct {
+	BaseURL         string
+	Workspace       string
+	UserAgent       string
+	Username        string
+	Password        string
+	Email           string
+	APIToken        string
+	TokenSource     oauth2.TokenSource
+	TokenRepository string
+	AuthMethod      string
+	HTTPClient      *http.Client
+	MaxRetries      int
+	RetryMinWait    time.Duration
+	RetryMaxWait    time.Duration
+	limiter         *rateLimiter
+	semaphore       chan struct{}
+	cache           *etagCache
+	environments    *environmentIndex
+	variables       *variableIndex
+	flights         *flightGroup
+	breaker         *circuitBreaker
+	PageLen         int
+
+	common service
+
+	BranchRestrictions    *BranchRestrictionsService
+	BranchingModel        *BranchingModelService
+	DefaultReviewers      *DefaultReviewersService
+	Deployments           *DeploymentsService
+	DeploymentVariables   *DeploymentVariablesService
+	DeployKeys            *DeployKeysService
+	Groups                *GroupsService
+	Hooks                 *HooksService
+	Issues                *IssuesService
+	Pipelines             *PipelinesService
+	PipelineSchedules     *PipelineSchedulesService
+	PipelineSSH           *PipelineSSHService
+	ProjectPermissions    *ProjectPermissionsService
+	Projects              *ProjectsService
+	PullRequests          *PullRequestsService
+	Refs                  *RefsService
+	Repositories          *RepositoriesService
+	RepositoryPermissions *RepositoryPermissionsService
+	RepositoryVariables   *RepositoryVariablesService
+	Snippets              *SnippetsService
+	Source                *SourceService
+	SSHKeys               *SSHKeysService
+	Users                 *UsersService
+	WorkspaceVariables    *WorkspaceVariablesService
+}
+
+// service is embedded by every service, they all share the Client they were created by.
+type service struct {
+	client *Client
+}
+
+// NewClient returns a Client that sends its requests with httpClient.
+func NewClient(httpClient *http.Client) *Client {
+	c := &Client{HTTPClient: httpClient, cache: newETagCache(), environments: newEnvironmentIndex(), variables: newVariableIndex(), flights: newFlightGroup()}
+	c.common.client = c
+
+	c.BranchRestrictions = (*BranchRestrictionsService)(&c.common)
+	c.BranchingModel = (*BranchingModelService)(&c.common)
+	c.DefaultReviewers = (*DefaultReviewersService)(&c.common)
+	c.Deployments = (*DeploymentsService)(&c.common)
+	c.DeploymentVariables = (*DeploymentVariablesService)(&c.common)
+	c.DeployKeys = (*DeployKeysService)(&c.common)
+	c.Groups = (*GroupsService)(&c.common)
+	c.Hooks = (*HooksService)(&c.common)
+	c.Issues = (*IssuesService)(&c.common)
+	c.Pipelines = (*PipelinesService)(&c.common)
+	c.PipelineSchedules = (*PipelineSchedulesService)(&c.common)
+	c.PipelineSSH = (*PipelineSSHService)(&c.common)
+	c.ProjectPermissions = (*ProjectPermissionsService)(&c.common)
+	c.Projects = (*ProjectsService)(&c.common)
+	c.PullRequests = (*PullRequestsService)(&c.common)
+	c.Refs = (*RefsService)(&c.common)
+	c.Repositories = (*RepositoriesService)(&c.common)
+	c.RepositoryPermissions = (*RepositoryPermissionsService)(&c.common)
+	c.RepositoryVariables = (*RepositoryVariablesService)(&c.common)
+	c.Snippets = (*SnippetsService)(&c.common)
+	c.Source = (*SourceService)(&c.common)
+	c.SSHKeys = (*SSHKeysService)(&c.common)
+	c.Users = (*UsersService)(&c.common)
+	c.WorkspaceVariables = (*WorkspaceVariablesService)(&c.common)
+
+	return c
+}
+
+// SetRateLimit caps the client at rps requests per second, zero leaves it unlimited.
+func (c *Client) SetRateLimit(rps float64) {
+	c.limiter = nil
+	if rps > 0 {
+		c.limiter = newRateLimiter(rps)
+	}
+}
+
+// SetParallelRequests caps how many requests the client has in flight at once, zero leaves it unlimited.
+func (c *Client) SetParallelRequests(n int) {
+	c.semaphore = nil
+	if n > 0 {
+		c.semaphore = make(chan struct{}, n)
+	}
+}
+
+// Do Will just call the bitbucket api but also add auth to it and some extra headers. The request is
+// abandoned once ctx is cancelled or its deadline has passed.
+func (c *Client) Do(ctx context.Context, method, endpoint string, payload *bytes.Buffer) (*http.Response, error) {
+
+	var body []byte
+
+	if payload != nil {
+		body = payload.Bytes()
+	}
+
+	return c.do(ctx, method, endpoint, body, "application/json")
+}
+
+// do sends body as the contentType given and turns responses bitbucket rejected into an Error.
+func (c *Client) do(ctx context.Context, method, endpoint string, body []byte, contentType string) (*http.Response, error) {
+	if err := c.checkTokenRepository(endpoint); err != nil {
+		return nil, err
+	}
+
+	if err := c.breaker.allow(method, endpoint); err != nil {
+		return nil, err
+	}
+
+	absoluteendpoint := strings.TrimSuffix(c.baseURL(), "/") + "/" + strings.TrimPrefix(endpoint, "/")
+
+	resp, err := c.send(ctx, method, absoluteendpoint, body, contentType)
+	c.breaker.record(ctx, method, endpoint, resp, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 || resp.StatusCode < 200 {
+		apiError := Error{
+			StatusCode: resp.StatusCode,
+			Endpoint:   endpoint,
+			RequestID:  resp.Header.Get("X-Request-Id"),
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(body, &apiError)
+		if err != nil {
+			apiError.APIError.Message = truncateBody(body)
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			apiError.Hint = c.authHint(method, endpoint, apiError)
+		}
+
+		return resp, error(apiError)
+
+	}
+	return resp, err
+}
+
+// baseURL is where requests are sent, BitbucketEndpoint unless BaseURL is set
+func (c *Client) baseURL() string {
+	if c.BaseURL == "" {
+		return BitbucketEndpoint
+	}
+	return c.BaseURL
+}
+
+// newRequest builds a single request with the auth and headers bitbucket needs. It is called for
+// every attempt so a retried request gets a fresh body and token.
+func (c *Client) newRequest(ctx context.Context, method, absoluteendpoint string, body []byte, contentType string) (*http.Request, error) {
+	var bodyreader io.Reader
+
+	if body != nil {
+		bodyreader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, absoluteendpoint, bodyreader)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.TokenSource != nil {
+		token, err := c.TokenSource.Token()
+		if err != nil {
+			return nil, fmt.Errorf("Could not obtain an OAuth access token: %s", err)
+		}
+		token.SetAuthHeader(req)
+	} else if c.Email != "" && c.APIToken != "" {
+		req.SetBasicAuth(c.Email, c.APIToken)
+	} else {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	if body != nil {
+		// Can cause bad request when putting default reviews if set.
+		req.Header.Add("Content-Type", contentType)
+	}
+
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	// Asked for explicitly so responses are compressed whatever transport the http client uses, see
+	// decompress.
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	return req, nil
+}
+
+// roundTrip sends a single request once the rate limiter and the cap on parallel requests allow it.
+// The body is read and closed before the slot is handed back, so a slot covers the whole exchange with
+// bitbucket and the connection goes back to the pool whatever the caller does with the response.
+func (c *Client) roundTrip(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.semaphore != nil {
+		select {
+		case c.semaphore <- struct{}{}:
+			defer func() { <-c.semaphore }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if c.cache != nil && req.Method == "GET" {
+		c.cache.prepare(req)
+	}
+
+	logRequest(req)
+	start := time.Now()
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		log.Printf("[DEBUG] Bitbucket API Request %s %s failed: %s", req.Method, req.URL, err)
+		return nil, err
+	}
+
+	body, err := decompress(resp)
+	if err != nil {
+		return nil, err
+	}
+	logResponse(req, resp, body, time.Since(start))
+
+	if c.cache != nil && req.Method == "GET" {
+		body = c.cache.update(req, resp, body)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// doJSON sends in as the JSON body of a request and decodes the response into out, either may be nil.
+// The response is handed back so callers can look at the status code.
+func (c *Client) doJSON(ctx context.Context, method, endpoint string, in, out interface{}) (*http.Response, error) {
+	var payload *bytes.Buffer
+
+	if in != nil {
+		bytedata, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		payload = bytes.NewBuffer(bytedata)
+	}
+
+	resp, err := c.Do(ctx, method, endpoint, payload)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(body) == 0 {
+		return resp, nil
+	}
+
+	return resp, decodeJSON(endpoint, body, out)
+}
+
+// Get is just a helper method to do but with a GET verb
+func (c *Client) Get(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.Do(ctx, "GET", endpoint, nil)
+}
+
+// GetList is a helper to Get a paginated collection, it asks for PageLen values per page when set
+func (c *Client) GetList(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.Get(ctx, c.withPageLen(endpoint))
+}
+
+// withPageLen adds the pagelen parameter to an endpoint unless it already asks for a page size
+func (c *Client) withPageLen(endpoint string) string {
+	if c.PageLen <= 0 || strings.Contains(endpoint, "pagelen=") {
+		return endpoint
+	}
+
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	return fmt.Sprintf("%s%spagelen=%d", endpoint, separator, c.PageLen)
+}
+
+// Post is just a helper method to do but with a POST verb
+func (c *Client) Post(ctx context.Context, endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
+	return c.Do(ctx, "POST", endpoint, jsonpayload)
+}
+
+// Put is just a helper method to do but with a PUT verb
+func (c *Client) Put(ctx context.Context, endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
+	return c.Do(ctx, "PUT", endpoint, jsonpayload)
+}
+
+// Patch is just a helper method to do but with a PATCH verb, for endpoints that only update the fields
+// they are sent
+func (c *Client) Patch(ctx context.Context, endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
+	return c.Do(ctx, "PATCH", endpoint, jsonpayload)
+}
+
+// PutOnly is just a helper method to do but with a PUT verb and a nil body
+func (c *Client) PutOnly(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.Do(ctx, "PUT", endpoint, nil)
+}
+
+// Delete is just a helper to Do but with a DELETE verb
+func (c *Client) Delete(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.Do(ctx, "DELETE", endpoint, nil)
+}
+
+// checkTokenRepository makes sure a repository access token is only used against the repository it was
+// issued for, bitbucket would otherwise answer with a 403 or 404 that says nothing about the token.
+func (c *Client) checkTokenRepository(endpoint string) error {
+	if c.TokenRepository == "" {
+		return nil
+	}
+
+	parts := strings.Split(strings.SplitN(endpoint, "?", 2)[0], "/")
+	if len(parts) < 4 || parts[0] != "2.0" || parts[1] != "repositories" {
+		return nil
+	}
+
+	owner, _ := url.PathUnescape(parts[2])
+	slug, _ := url.PathUnescape(parts[3])
+
+	repository := fmt.Sprintf("%s/%s", owner, slug)
+	if !strings.EqualFold(repository, c.TokenRepository) {
+		return fmt.Errorf("The %s is scoped to %s and cannot be used to manage %s", c.AuthMethod, c.TokenRepository, repository)
+	}
+
+	return nil
+}
+
+// CredentialsEndpoint is a cheap endpoint the configured credentials can read: what an access token was
+// issued for, or the authenticated user. ok is false for a workspace access token without a Workspace,
+// there is nothing to check it against.
+func (c *Client) CredentialsEndpoint() (endpoint string, ok bool) {
+	switch c.AuthMethod {
+	case AuthMethodRepositoryToken:
+		parts := strings.SplitN(c.TokenRepository, "/", 2)
+		if len(parts) != 2 {
+			return "", false
+		}
+		return apiPath("2.0/repositories/%s/%s", parts[0], parts[1]), true
+	case AuthMethodWorkspaceToken:
+		if c.Workspace == "" {
+			return "", false
+		}
+		return apiPath("2.0/workspaces/%s", c.Workspace), true
+	}
+
+	return "2.0/user", true
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientWithPageLen(t *testing.T) {
+	client := &Client{PageLen: 50}
+
+	cases := map[string]string{
+		"2.0/repositories/gob/illusions/default-reviewers":        "2.0/repositories/gob/illusions/default-reviewers?pagelen=50",
+		"2.0/repositories/gob/illusions/default-reviewers?page=2": "2.0/repositories/gob/illusions/default-reviewers?page=2&pagelen=50",
+		"2.0/repositories/gob/illusions/environments/?pagelen=10": "2.0/repositories/gob/illusions/environments/?pagelen=10",
+	}
+
+	for endpoint, expected := range cases {
+		if got := client.withPageLen(endpoint); got != expected {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	}
+
+	client.PageLen = 0
+	if got := client.withPageLen("2.0/user"); got != "2.0/user" {
+		t.Fatalf("expected the endpoint to be left alone without a pagelen, got %q", got)
+	}
+}
+
+func TestClientDoJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/repositories/gob/illusions/pipelines_config/variables/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var variable RepositoryVariable
+		if err := json.NewDecoder(r.Body).Decode(&variable); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		variable.UUID = "{uuid}"
+		json.NewEncoder(w).Encode(variable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	created, err := client.RepositoryVariables.Create(context.Background(), "gob", "illusions", &RepositoryVariable{Key: "MAGIC"})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if created.Key != "MAGIC" || created.UUID != "{uuid}" {
+		t.Fatalf("expected the created variable to be decoded, got %+v", created)
+	}
+
+	_, err = client.RepositoryVariables.Get(context.Background(), "gob", "mirrors", "{uuid}")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestClientReusesConnections(t *testing.T) {
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"type": "error", "error": {"message": "gone"}}`))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get(context.Background(), "2.0/user"); !IsNotFound(err) {
+			t.Fatalf("expected a not found error, got %v", err)
+		}
+	}
+
+	if n := atomic.LoadInt32(&connections); n != 1 {
+		t.Fatalf("expected the requests to share one connection, got %d", n)
+	}
+}
+
+func TestClientPatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.Header.Get("Content-Type") != "application/json" {
+			t.Fatalf("expected a JSON PATCH, got %s with %q", r.Method, r.Header.Get("Content-Type"))
+		}
+
+		var runner map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&runner); err != nil || runner["name"] != "illusions" {
+			t.Fatalf("expected the body to be sent, got %v (%v)", runner, err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	if _, err := client.Patch(context.Background(), "2.0/workspaces/gob/pipelines-config/runners/%7Brunner%7D", bytes.NewBufferString(`{"name": "illusions"}`)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestClientCredentialsEndpoint(t *testing.T) {
+	cases := []struct {
+		client   Client
+		endpoint string
+		ok       bool
+	}{
+		{Client{AuthMethod: AuthMethodPassword}, "2.0/user", true},
+		{Client{AuthMethod: AuthMethodRepositoryToken, TokenRepository: "gob/illusions"}, "2.0/repositories/gob/illusions", true},
+		{Client{AuthMethod: AuthMethodRepositoryToken, TokenRepository: "gob/magic tricks"}, "2.0/repositories/gob/magic%20tricks", true},
+		{Client{AuthMethod: AuthMethodWorkspaceToken, Workspace: "{gob}"}, "2.0/workspaces/%7Bgob%7D", true},
+		{Client{AuthMethod: AuthMethodWorkspaceToken}, "", false},
+	}
+
+	for _, tc := range cases {
+		if endpoint, ok := tc.client.CredentialsEndpoint(); endpoint != tc.endpoint || ok != tc.ok {
+			t.Fatalf("expected the %s to be checked against %q (%t), got %q (%t)", tc.client.AuthMethod, tc.endpoint, tc.ok, endpoint, ok)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// maxDecodeErrorBody is how much of a body that could not be decoded is shown in the error
+const maxDecodeErrorBody = 200
+
+// DecodeError is a response of bitbucket that is not the JSON it should be. Gateways and proxies answer
+// with HTML pages, the error shows the start of the body so it is clear who answered.
+type DecodeError struct {
+	Endpoint string
+	Body     string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	message := fmt.Sprintf("Could not decode the response of %s: %s, the response was: %s", e.Endpoint, e.Err, e.Body)
+	if looksLikeHTML([]byte(e.Body)) {
+		message += " (this is an HTML page, not the Bitbucket API, check base_url and proxy_url)"
+	}
+	return message
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeJSON decodes the body of a response from endpoint into out
+func decodeJSON(endpoint string, body []byte, out interface{}) error {
+	if err := json.Unmarshal(body, out); err != nil {
+		return &DecodeError{Endpoint: endpoint, Body: truncateBody(body), Err: err}
+	}
+	return nil
+}
+
+// truncateBody shortens body to what is worth showing in an error
+func truncateBody(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) > maxDecodeErrorBody {
+		return string(body[:maxDecodeErrorBody]) + "..."
+	}
+	return string(body)
+}
+
+func looksLikeHTML(body []byte) bool {
+	body = bytes.TrimSpace(body)
+	return len(body) > 0 && body[0] == '<'
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientDecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "<html><body>%s</body></html>", strings.Repeat("Please log in to the proxy. ", 20))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	_, err := client.Repositories.Get(context.Background(), "gob", "illusions")
+
+	decodeError, ok := err.(*DecodeError)
+	if !ok {
+		t.Fatalf("expected a DecodeError, got %v", err)
+	}
+
+	if decodeError.Endpoint != "2.0/repositories/gob/illusions" || len(decodeError.Body) > maxDecodeErrorBody+3 {
+		t.Fatalf("expected the endpoint and a truncated body, got %+v", decodeError)
+	}
+
+	if message := err.Error(); !strings.Contains(message, "the response was: <html><body>Please log in") || !strings.Contains(message, "this is an HTML page") {
+		t.Fatalf("expected the error to show what answered, got %q", message)
+	}
+}
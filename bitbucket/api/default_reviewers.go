@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// DefaultReviewersService talks to the default reviewer endpoints of a repository.
+type DefaultReviewersService service
+
+// Reviewer is teh default reviewer you want
+type Reviewer struct {
+	DisplayName string `json:"display_name,omitempty"`
+	UUID        string `json:"uuid,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// List fetches every default reviewer of a repository.
+func (s *DefaultReviewersService) List(ctx context.Context, owner, slug string) ([]Reviewer, error) {
+	var reviewers []Reviewer
+	endpoint := apiPath("2.0/repositories/%s/%s/default-reviewers", owner, slug)
+
+	err := s.client.listPages(ctx, endpoint, func(values json.RawMessage) error {
+		var page []Reviewer
+		if err := decodeJSON(endpoint, values, &page); err != nil {
+			return err
+		}
+		reviewers = append(reviewers, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reviewers, nil
+}
+
+// Add makes user a default reviewer of a repository.
+func (s *DefaultReviewersService) Add(ctx context.Context, owner, slug, user string) error {
+	resp, err := s.client.doJSON(ctx, "PUT", apiPath("2.0/repositories/%s/%s/default-reviewers/%s", owner, slug, user), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed to create reviewer %s got code %d", user, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Remove stops user from being a default reviewer of a repository.
+func (s *DefaultReviewersService) Remove(ctx context.Context, owner, slug, user string) error {
+	resp, err := s.client.doJSON(ctx, "DELETE", apiPath("2.0/repositories/%s/%s/default-reviewers/%s", owner, slug, user), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("[%d] Could not delete %s from default reviewer", resp.StatusCode, user)
+	}
+
+	return nil
+}
+
+// ProjectReviewer is a default reviewer of a project, the repositories of the project add them to their
+// pull requests.
+type ProjectReviewer struct {
+	User         *Reviewer `json:"user,omitempty"`
+	ReviewerType string    `json:"reviewer_type,omitempty"`
+}
+
+func projectDefaultReviewersEndpoint(owner, key string) string {
+	return apiPath("2.0/workspaces/%s/projects/%s/default-reviewers", owner, key)
+}
+
+// ListForProject fetches every default reviewer of a project.
+func (s *DefaultReviewersService) ListForProject(ctx context.Context, owner, key string) ([]Reviewer, error) {
+	var reviewers []Reviewer
+	endpoint := projectDefaultReviewersEndpoint(owner, key)
+
+	err := s.client.listPages(ctx, endpoint, func(values json.RawMessage) error {
+		var page []ProjectReviewer
+		if err := decodeJSON(endpoint, values, &page); err != nil {
+			return err
+		}
+		for _, reviewer := range page {
+			if reviewer.User != nil {
+				reviewers = append(reviewers, *reviewer.User)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reviewers, nil
+}
+
+// AddForProject makes user a default reviewer of a project.
+func (s *DefaultReviewersService) AddForProject(ctx context.Context, owner, key, user string) error {
+	resp, err := s.client.doJSON(ctx, "PUT", apiPath("2.0/workspaces/%s/projects/%s/default-reviewers/%s", owner, key, user), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed to create project reviewer %s got code %d", user, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// RemoveForProject stops user from being a default reviewer of a project.
+func (s *DefaultReviewersService) RemoveForProject(ctx context.Context, owner, key, user string) error {
+	resp, err := s.client.doJSON(ctx, "DELETE", apiPath("2.0/workspaces/%s/projects/%s/default-reviewers/%s", owner, key, user), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("[%d] Could not delete %s from project default reviewers", resp.StatusCode, user)
+	}
+
+	return nil
+}
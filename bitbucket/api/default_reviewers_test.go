@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDefaultReviewersListForProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/2.0/workspaces/gob/projects/MAGIC/default-reviewers" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[{"reviewer_type":"project","user":{"uuid":"{buster}","display_name":"Buster"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	reviewers, err := client.DefaultReviewers.ListForProject(context.Background(), "gob", "MAGIC")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(reviewers) != 1 || reviewers[0].UUID != "{buster}" {
+		t.Fatalf("expected the reviewer {buster}, got %+v", reviewers)
+	}
+}
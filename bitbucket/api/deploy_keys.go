@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+)
+
+// DeployKeysService talks to the access key endpoints of a repository.
+type DeployKeysService service
+
+// DeployKey is a public key with read access to a repository. Bitbucket splits the comment off the key
+// and keeps it as Comment.
+type DeployKey struct {
+	ID        int    `json:"id,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Label     string `json:"label,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedOn string `json:"created_on,omitempty"`
+	LastUsed  string `json:"last_used,omitempty"`
+}
+
+func deployKeyEndpoint(owner, slug, id string) string {
+	return apiPath("2.0/repositories/%s/%s/deploy-keys/%s", owner, slug, id)
+}
+
+// Get fetches the deploy key with the given id.
+func (s *DeployKeysService) Get(ctx context.Context, owner, slug, id string) (*DeployKey, error) {
+	var key DeployKey
+	_, err := s.client.doJSON(ctx, "GET", deployKeyEndpoint(owner, slug, id), nil, &key)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Create adds a deploy key to a repository.
+func (s *DeployKeysService) Create(ctx context.Context, owner, slug string, key *DeployKey) (*DeployKey, error) {
+	var created DeployKey
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/deploy-keys", owner, slug), key, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the label of the deploy key with the given id, bitbucket wants the key along with it.
+func (s *DeployKeysService) Update(ctx context.Context, owner, slug, id string, key *DeployKey) (*DeployKey, error) {
+	var updated DeployKey
+	_, err := s.client.doJSON(ctx, "PUT", deployKeyEndpoint(owner, slug, id), key, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the deploy key with the given id.
+func (s *DeployKeysService) Delete(ctx context.Context, owner, slug, id string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", deployKeyEndpoint(owner, slug, id), nil, nil)
+	return err
+}
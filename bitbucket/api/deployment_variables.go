@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// DeploymentVariablesService talks to the variable endpoints of a deployment.
+type DeploymentVariablesService service
+
+// DeploymentVariable structure for handling key info
+type DeploymentVariable struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	UUID    string `json:"uuid,omitempty"`
+	Secured bool   `json:"secured"`
+}
+
+func deploymentVariablesEndpoint(owner, slug, deployment string) string {
+	return apiPath("2.0/repositories/%s/%s/deployments_config/environments/%s/variables", owner, slug, deployment)
+}
+
+// List fetches every variable of a deployment. It always asks bitbucket, what it gets is remembered for
+// Get.
+func (s *DeploymentVariablesService) List(ctx context.Context, owner, slug, deployment string) ([]DeploymentVariable, error) {
+	var variables []DeploymentVariable
+	endpoint := deploymentVariablesEndpoint(owner, slug, deployment)
+
+	err := s.client.listPages(ctx, endpoint, func(values json.RawMessage) error {
+		var page []DeploymentVariable
+		if err := decodeJSON(endpoint, values, &page); err != nil {
+			return err
+		}
+		variables = append(variables, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.client.variables.set(owner, slug, deployment, variables)
+	return variables, nil
+}
+
+// Get looks up the variable with the given uuid. Bitbucket has no endpoint for a single deployment
+// variable, it is answered from the last List of the deployment when that had it, and the variables are
+// listed again otherwise. This way refreshing every variable of an environment lists them only once. A
+// variable that isn't listed is reported like a 404 so IsNotFound works as for any other object.
+func (s *DeploymentVariablesService) Get(ctx context.Context, owner, slug, deployment, uuid string) (*DeploymentVariable, error) {
+	if variable, ok := s.client.variables.get(owner, slug, deployment, uuid); ok {
+		return &variable, nil
+	}
+
+	variables, err := s.List(ctx, owner, slug, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range variables {
+		if variables[i].UUID == uuid {
+			return &variables[i], nil
+		}
+	}
+
+	notFound := Error{StatusCode: http.StatusNotFound, Endpoint: deploymentVariablesEndpoint(owner, slug, deployment)}
+	notFound.APIError.Message = fmt.Sprintf("Variable %s not found", uuid)
+	return nil, notFound
+}
+
+// Create adds a variable to a deployment.
+func (s *DeploymentVariablesService) Create(ctx context.Context, owner, slug, deployment string, variable *DeploymentVariable) (*DeploymentVariable, error) {
+	var created DeploymentVariable
+	_, err := s.client.doJSON(ctx, "POST", deploymentVariablesEndpoint(owner, slug, deployment), variable, &created)
+	s.client.variables.forget(owner, slug, deployment)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the deployment variable with the given uuid.
+func (s *DeploymentVariablesService) Update(ctx context.Context, owner, slug, deployment, uuid string, variable *DeploymentVariable) (*DeploymentVariable, error) {
+	var updated DeploymentVariable
+	_, err := s.client.doJSON(ctx, "PUT", apiPath("2.0/repositories/%s/%s/deployments_config/environments/%s/variables/%s", owner, slug, deployment, uuid), variable, &updated)
+	s.client.variables.forget(owner, slug, deployment)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the deployment variable with the given uuid.
+func (s *DeploymentVariablesService) Delete(ctx context.Context, owner, slug, deployment, uuid string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", apiPath("2.0/repositories/%s/%s/deployments_config/environments/%s/variables/%s", owner, slug, deployment, uuid), nil, nil)
+	s.client.variables.forget(owner, slug, deployment)
+	return err
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// DeploymentsService talks to the deployment environment endpoints of a repository.
+type DeploymentsService service
+
+// Deployment structure for handling key info
+type Deployment struct {
+	Name         string        `json:"name"`
+	Stage        *Stage        `json:"environment_type"`
+	UUID         string        `json:"uuid,omitempty"`
+	Rank         int           `json:"rank,omitempty"`
+	Category     *Category     `json:"category,omitempty"`
+	Restrictions *Restrictions `json:"restrictions,omitempty"`
+	Lock         *Lock         `json:"lock,omitempty"`
+}
+
+// Restrictions decide who may deploy to an environment, admin only is a premium feature
+type Restrictions struct {
+	AdminOnly bool `json:"admin_only"`
+}
+
+// DeploymentChange is what can be changed about a deployment after it was created, the type of its
+// environment can't.
+type DeploymentChange struct {
+	Name         string        `json:"name,omitempty"`
+	Rank         int           `json:"rank,omitempty"`
+	Restrictions *Restrictions `json:"restrictions,omitempty"`
+	Lock         *Lock         `json:"lock,omitempty"`
+}
+
+// Lock tells whether deployments to an environment are allowed, a closed lock holds every deployment
+// to it until it is opened again.
+type Lock struct {
+	Name string `json:"name"`
+}
+
+// The names of the locks of an environment
+const (
+	LockOpen   = "OPEN"
+	LockClosed = "CLOSED"
+)
+
+// Stage is the environment type of a deployment, one of Test, Staging or Production
+type Stage struct {
+	Name string `json:"name"`
+}
+
+// Category is the category bitbucket files a deployment under
+type Category struct {
+	Name string `json:"name"`
+}
+
+func deploymentsEndpoint(owner, slug string) string {
+	return apiPath("2.0/repositories/%s/%s/environments/", owner, slug)
+}
+
+// List fetches every deployment of a repository.
+func (s *DeploymentsService) List(ctx context.Context, owner, slug string) ([]Deployment, error) {
+	var deployments []Deployment
+	endpoint := deploymentsEndpoint(owner, slug)
+
+	err := s.client.listPages(ctx, endpoint, func(values json.RawMessage) error {
+		var page []Deployment
+		if err := decodeJSON(endpoint, values, &page); err != nil {
+			return err
+		}
+		deployments = append(deployments, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deployments, nil
+}
+
+// Get fetches the deployment with the given uuid.
+func (s *DeploymentsService) Get(ctx context.Context, owner, slug, uuid string) (*Deployment, error) {
+	var deployment Deployment
+	_, err := s.client.doJSON(ctx, "GET", apiPath("2.0/repositories/%s/%s/environments/%s", owner, slug, uuid), nil, &deployment)
+	if err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+// Create adds a deployment to a repository.
+func (s *DeploymentsService) Create(ctx context.Context, owner, slug string, deployment *Deployment) (*Deployment, error) {
+	var created Deployment
+	_, err := s.client.doJSON(ctx, "POST", deploymentsEndpoint(owner, slug), deployment, &created)
+	s.client.environments.forget(owner, slug)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the deployment with the given uuid. Bitbucket applies changes to environments
+// asynchronously, it only acknowledges them.
+func (s *DeploymentsService) Update(ctx context.Context, owner, slug, uuid string, change *DeploymentChange) error {
+	body := struct {
+		Change *DeploymentChange `json:"change"`
+	}{change}
+
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/environments/%s/changes/", owner, slug, uuid), body, nil)
+	s.client.environments.forget(owner, slug)
+	return err
+}
+
+// Delete removes the deployment with the given uuid.
+func (s *DeploymentsService) Delete(ctx context.Context, owner, slug, uuid string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", apiPath("2.0/repositories/%s/%s/environments/%s", owner, slug, uuid), nil, nil)
+	s.client.environments.forget(owner, slug)
+	s.client.variables.forget(owner, slug, uuid)
+	return err
+}
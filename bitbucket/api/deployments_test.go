@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeploymentsUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/2.0/repositories/gob/illusions/environments/{staging}/changes/" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"change":{"name":"Stage","rank":2}}` {
+			t.Fatalf("unexpected body %s", body)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	if err := client.Deployments.Update(context.Background(), "gob", "illusions", "{staging}", &DeploymentChange{Name: "Stage", Rank: 2}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestDeploymentsUpdateLock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"change":{"lock":{"name":"CLOSED"}}}` {
+			t.Fatalf("unexpected body %s", body)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	if err := client.Deployments.Update(context.Background(), "gob", "illusions", "{production}", &DeploymentChange{Lock: &Lock{Name: LockClosed}}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// environmentIndex remembers the uuids of the deployment environments of every repository by name. The
+// first lookup for a repository lists its environments, every later one is answered from memory so many
+// variables targeting environments of the same repository don't each list them again.
+type environmentIndex struct {
+	mu    sync.Mutex
+	repos map[string]*environmentNames
+}
+
+// environmentNames are the environments of one repository, uuids by lower case name and names by uuid
+type environmentNames struct {
+	uuids map[string]string
+	names map[string]string
+}
+
+func newEnvironmentIndex() *environmentIndex {
+	return &environmentIndex{repos: make(map[string]*environmentNames)}
+}
+
+func (i *environmentIndex) get(owner, slug string) (*environmentNames, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	environments, ok := i.repos[owner+"/"+slug]
+	return environments, ok
+}
+
+func (i *environmentIndex) set(owner, slug string, environments *environmentNames) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.repos[owner+"/"+slug] = environments
+}
+
+// forget drops what is known about a repository, its environments changed
+func (i *environmentIndex) forget(owner, slug string) {
+	if i == nil {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.repos, owner+"/"+slug)
+}
+
+// environmentsOf lists the environments of a repository once per client
+func (s *DeploymentsService) environmentsOf(ctx context.Context, owner, slug string) (*environmentNames, error) {
+	index := s.client.environments
+	if index == nil {
+		index = newEnvironmentIndex()
+	}
+
+	environments, cached := index.get(owner, slug)
+	if cached {
+		return environments, nil
+	}
+
+	deployments, err := s.List(ctx, owner, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	environments = &environmentNames{
+		uuids: make(map[string]string, len(deployments)),
+		names: make(map[string]string, len(deployments)),
+	}
+	for _, deployment := range deployments {
+		environments.uuids[strings.ToLower(deployment.Name)] = deployment.UUID
+		environments.names[deployment.UUID] = deployment.Name
+	}
+	index.set(owner, slug, environments)
+
+	return environments, nil
+}
+
+// UUIDByName resolves the name of a deployment environment to its uuid, ignoring case. The environments of
+// a repository are only listed once per client, ok is false when the repository has no environment of
+// that name.
+func (s *DeploymentsService) UUIDByName(ctx context.Context, owner, slug, name string) (uuid string, ok bool, err error) {
+	environments, err := s.environmentsOf(ctx, owner, slug)
+	if err != nil {
+		return "", false, err
+	}
+
+	uuid, ok = environments.uuids[strings.ToLower(name)]
+	return uuid, ok, nil
+}
+
+// NameByUUID is the name of the deployment environment with the given uuid, answered from the same list
+// as UUIDByName. ok is false when the repository has no environment with that uuid.
+func (s *DeploymentsService) NameByUUID(ctx context.Context, owner, slug, uuid string) (name string, ok bool, err error) {
+	environments, err := s.environmentsOf(ctx, owner, slug)
+	if err != nil {
+		return "", false, err
+	}
+
+	name, ok = environments.names[uuid]
+	return name, ok, nil
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeploymentsUUIDByName(t *testing.T) {
+	listed := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			fmt.Fprint(w, `{"name": "Staging", "uuid": "{staging}"}`)
+			return
+		}
+
+		listed++
+		fmt.Fprint(w, `{"values": [{"name": "Test", "uuid": "{test}"}, {"name": "Production", "uuid": "{production}"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	for _, name := range []string{"Test", "Production", "Test"} {
+		uuid, ok, err := client.Deployments.UUIDByName(context.Background(), "gob", "illusions", name)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if !ok || uuid == "" {
+			t.Fatalf("expected %s to be found", name)
+		}
+	}
+
+	if listed != 1 {
+		t.Fatalf("expected the environments to be listed once, got %d", listed)
+	}
+
+	if _, ok, _ := client.Deployments.UUIDByName(context.Background(), "gob", "illusions", "Staging"); ok {
+		t.Fatalf("expected an unknown environment not to be found")
+	}
+
+	if _, err := client.Deployments.Create(context.Background(), "gob", "illusions", &Deployment{Name: "Staging"}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client.Deployments.UUIDByName(context.Background(), "gob", "illusions", "Test")
+	if listed != 2 {
+		t.Fatalf("expected the environments to be listed again after a change, got %d", listed)
+	}
+}
+
+func TestDeploymentsNameByUUID(t *testing.T) {
+	listed := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listed++
+		fmt.Fprint(w, `{"values": [{"name": "Production", "uuid": "{production}"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	if _, _, err := client.Deployments.UUIDByName(context.Background(), "gob", "illusions", "production"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	name, ok, err := client.Deployments.NameByUUID(context.Background(), "gob", "illusions", "{production}")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !ok || name != "Production" {
+		t.Fatalf("expected {production} to be named Production, got %q (%t)", name, ok)
+	}
+
+	if _, ok, _ := client.Deployments.NameByUUID(context.Background(), "gob", "illusions", "{staging}"); ok {
+		t.Fatalf("expected an unknown environment not to be found")
+	}
+
+	if listed != 1 {
+		t.Fatalf("expected the environments to be listed once, got %d", listed)
+	}
+}
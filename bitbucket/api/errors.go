@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Error represents a error from the bitbucket api. Fields holds the reasons bitbucket gave for
+// rejecting individual fields of the request, keyed by the field name. RequestID is the X-Request-Id
+// bitbucket tagged the response with, Atlassian support can look a failed request up by it.
+type Error struct {
+	APIError struct {
+		Message string                     `json:"message,omitempty"`
+		Detail  json.RawMessage            `json:"detail,omitempty"`
+		Fields  map[string]json.RawMessage `json:"fields,omitempty"`
+	} `json:"error,omitempty"`
+	Type       string `json:"type,omitempty"`
+	StatusCode int    `json:"-"`
+	Endpoint   string `json:"-"`
+	Hint       string `json:"-"`
+	RequestID  string `json:"-"`
+}
+
+func (e Error) Error() string {
+	message := fmt.Sprintf("API Error: %d %s %s", e.StatusCode, e.Endpoint, e.APIError.Message)
+
+	var detail string
+	if err := json.Unmarshal(e.APIError.Detail, &detail); err == nil && detail != "" {
+		message = fmt.Sprintf("%s: %s", message, detail)
+	}
+
+	if fields := e.FieldErrors(); len(fields) > 0 {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		reasons := make([]string, 0, len(names))
+		for _, name := range names {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", name, strings.Join(fields[name], ", ")))
+		}
+		message = fmt.Sprintf("%s [%s]", message, strings.Join(reasons, "; "))
+	}
+
+	if e.Hint != "" {
+		message = fmt.Sprintf("%s (%s)", message, e.Hint)
+	}
+
+	if e.RequestID != "" {
+		message = fmt.Sprintf("%s (request id: %s)", message, e.RequestID)
+	}
+
+	return message
+}
+
+// FieldErrors returns the reasons bitbucket rejected each field for. Bitbucket sends either a single
+// reason or a list of them per field.
+func (e Error) FieldErrors() map[string][]string {
+	fields := make(map[string][]string, len(e.APIError.Fields))
+
+	for name, raw := range e.APIError.Fields {
+		var reasons []string
+		if err := json.Unmarshal(raw, &reasons); err != nil {
+			var reason string
+			if err := json.Unmarshal(raw, &reason); err != nil {
+				reason = string(raw)
+			}
+			reasons = []string{reason}
+		}
+		fields[name] = reasons
+	}
+
+	return fields
+}
+
+// scopeDetail is the detail bitbucket sends back when the credentials lack a privilege scope
+type scopeDetail struct {
+	Required []string `json:"required,omitempty"`
+	Granted  []string `json:"granted,omitempty"`
+}
+
+// IsNotFound reports whether err is bitbucket saying the requested object does not exist.
+func IsNotFound(err error) bool {
+	apiError, ok := err.(Error)
+	return ok && apiError.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"type": "error", "error": {"message": "Bad request", "detail": "The variable is invalid.", "fields": {"key": ["This field is required.", "Is not a valid key."], "value": "Too long."}}}`)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	_, err := client.Post(context.Background(), "2.0/repositories/gob/illusions/pipelines_config/variables/", nil)
+	if err == nil {
+		t.Fatalf("expected an error for the 400")
+	}
+
+	expected := "API Error: 400 2.0/repositories/gob/illusions/pipelines_config/variables/ Bad request: The variable is invalid. [key: This field is required., Is not a valid key.; value: Too long.]"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+
+	if fields := err.(Error).FieldErrors(); len(fields["key"]) != 2 || fields["value"][0] != "Too long." {
+		t.Fatalf("expected the field errors to be decoded, got %v", fields)
+	}
+}
+
+func TestClientError_requestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "4f3a1c2b9d")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"type": "error", "error": {"message": "Repository not found"}}`)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	_, err := client.Get(context.Background(), "2.0/repositories/gob/illusions")
+
+	expected := "API Error: 404 2.0/repositories/gob/illusions Repository not found (request id: 4f3a1c2b9d)"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %v", expected, err)
+	}
+}
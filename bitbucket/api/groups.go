@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+)
+
+// GroupsService talks to the group endpoints of a workspace, they only exist in the 1.0 API.
+type GroupsService service
+
+// WorkspaceGroup is a group of users of a workspace. Permission is what the group gets on the
+// repositories of the workspace, nil for none, and AutoAdd adds the group to new repositories with it.
+type WorkspaceGroup struct {
+	Name                    string  `json:"name,omitempty"`
+	Slug                    string  `json:"slug,omitempty"`
+	Permission              *string `json:"permission"`
+	AutoAdd                 bool    `json:"auto_add"`
+	EmailForwardingDisabled bool    `json:"email_forwarding_disabled"`
+	Members                 []User  `json:"members,omitempty"`
+}
+
+func groupEndpoint(workspace, slug string) string {
+	return apiPath("1.0/groups/%s/%s/", workspace, slug)
+}
+
+// Get fetches the group of a workspace with the given slug.
+func (s *GroupsService) Get(ctx context.Context, workspace, slug string) (*WorkspaceGroup, error) {
+	var group WorkspaceGroup
+	_, err := s.client.doJSON(ctx, "GET", groupEndpoint(workspace, slug), nil, &group)
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// Create adds a group named name to a workspace, bitbucket derives the slug from the name. The 1.0 API
+// only takes the name as a form.
+func (s *GroupsService) Create(ctx context.Context, workspace, name string) (*WorkspaceGroup, error) {
+	endpoint := apiPath("1.0/groups/%s/", workspace)
+	form := url.Values{"name": []string{name}}
+
+	resp, err := s.client.do(ctx, "POST", endpoint, []byte(form.Encode()), "application/x-www-form-urlencoded")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var created WorkspaceGroup
+	if err := decodeJSON(endpoint, body, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the group of a workspace with the given slug.
+func (s *GroupsService) Update(ctx context.Context, workspace, slug string, group *WorkspaceGroup) (*WorkspaceGroup, error) {
+	var updated WorkspaceGroup
+	_, err := s.client.doJSON(ctx, "PUT", groupEndpoint(workspace, slug), group, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the group of a workspace with the given slug.
+func (s *GroupsService) Delete(ctx context.Context, workspace, slug string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", groupEndpoint(workspace, slug), nil, nil)
+	return err
+}
+
+func groupMemberEndpoint(workspace, slug, user string) string {
+	return apiPath("1.0/groups/%s/%s/members/%s/", workspace, slug, user)
+}
+
+// ListMembers fetches every member of the group of a workspace with the given slug, following the pages
+// of the 1.0 API when it pages them.
+func (s *GroupsService) ListMembers(ctx context.Context, workspace, slug string) ([]User, error) {
+	var members []User
+	endpoint := apiPath("1.0/groups/%s/%s/members/", workspace, slug)
+
+	err := s.client.listLegacyPages(ctx, endpoint, func(values json.RawMessage) error {
+		var page []User
+		if err := decodeJSON(endpoint, values, &page); err != nil {
+			return err
+		}
+		members = append(members, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddMember adds user, given by its uuid, to the group of a workspace. Bitbucket wants an empty JSON
+// object as the body.
+func (s *GroupsService) AddMember(ctx context.Context, workspace, slug, user string) error {
+	_, err := s.client.doJSON(ctx, "PUT", groupMemberEndpoint(workspace, slug, user), struct{}{}, nil)
+	return err
+}
+
+// RemoveMember removes user, given by its uuid, from the group of a workspace.
+func (s *GroupsService) RemoveMember(ctx context.Context, workspace, slug, user string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", groupMemberEndpoint(workspace, slug, user), nil, nil)
+	return err
+}
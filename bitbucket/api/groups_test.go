@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGroupsCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/1.0/groups/gob/" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" || r.FormValue("name") != "The Alliance" {
+			t.Fatalf("expected the name as a form, got %s %q", r.Header.Get("Content-Type"), r.FormValue("name"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"The Alliance","slug":"the-alliance","permission":null,"auto_add":false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	group, err := client.Groups.Create(context.Background(), "gob", "The Alliance")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if group.Slug != "the-alliance" || group.Permission != nil {
+		t.Fatalf("unexpected group %+v", group)
+	}
+}
+
+func TestGroupsListMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1.0/groups/gob/magicians/members/" {
+			t.Fatalf("unexpected request for %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("start") {
+		case "":
+			w.Write([]byte(`{"values": [{"uuid": "{gob}"}], "start": 0, "count": 2}`))
+		case "1":
+			w.Write([]byte(`{"values": [{"uuid": "{buster}"}], "start": 1, "count": 2}`))
+		default:
+			t.Fatalf("unexpected start %s", r.URL.Query().Get("start"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	members, err := client.Groups.ListMembers(context.Background(), "gob", "magicians")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(members) != 2 || members[1].UUID != "{buster}" {
+		t.Fatalf("expected the members of both pages, got %+v", members)
+	}
+}
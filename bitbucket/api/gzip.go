@@ -0,0 +1,37 @@
+package api
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// decompress reads and closes the body of resp, gunzipping it when bitbucket compressed it. Large lists
+// of variables or repositories shrink to a fraction of their size. The response is changed to look as if
+// it was sent uncompressed.
+func decompress(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return ioutil.ReadAll(resp.Body)
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = int64(len(body))
+	resp.Uncompressed = true
+
+	return body, nil
+}
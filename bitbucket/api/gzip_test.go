@@ -0,0 +1,35 @@
+package api
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientGzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Fatalf("expected a compressed response to be asked for, got %q", r.Header.Get("Accept-Encoding"))
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		writer.Write([]byte(`{"values": [{"key": "PASSWORD", "uuid": "{password}"}]}`))
+		writer.Close()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	variables, err := client.DeploymentVariables.List(context.Background(), "gob", "illusions", "{staging}")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(variables) != 1 || variables[0].Key != "PASSWORD" {
+		t.Fatalf("expected the compressed response to be decoded, got %+v", variables)
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+)
+
+// HooksService talks to the webhook endpoints of repositories and workspaces.
+type HooksService service
+
+// Hook is the hook you want to add to a bitbucket repository or workspace. Active and
+// SkipCertVerification are always sent, bitbucket would keep what a hook had otherwise.
+type Hook struct {
+	UUID                 string   `json:"uuid,omitempty"`
+	URL                  string   `json:"url,omitempty"`
+	Description          string   `json:"description,omitempty"`
+	Active               bool     `json:"active"`
+	SkipCertVerification bool     `json:"skip_cert_verification"`
+	Events               []string `json:"events,omitempty"`
+}
+
+func hookEndpoint(owner, slug, uuid string) string {
+	return apiPath("2.0/repositories/%s/%s/hooks/%s", owner, slug, uuid)
+}
+
+// Get fetches the hook with the given uuid.
+func (s *HooksService) Get(ctx context.Context, owner, slug, uuid string) (*Hook, error) {
+	var hook Hook
+	_, err := s.client.doJSON(ctx, "GET", hookEndpoint(owner, slug, uuid), nil, &hook)
+	if err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// Create adds a hook to a repository.
+func (s *HooksService) Create(ctx context.Context, owner, slug string, hook *Hook) (*Hook, error) {
+	var created Hook
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/hooks", owner, slug), hook, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the hook with the given uuid.
+func (s *HooksService) Update(ctx context.Context, owner, slug, uuid string, hook *Hook) (*Hook, error) {
+	var updated Hook
+	_, err := s.client.doJSON(ctx, "PUT", hookEndpoint(owner, slug, uuid), hook, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the hook with the given uuid.
+func (s *HooksService) Delete(ctx context.Context, owner, slug, uuid string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", hookEndpoint(owner, slug, uuid), nil, nil)
+	return err
+}
+
+func workspaceHookEndpoint(workspace, uuid string) string {
+	return apiPath("2.0/workspaces/%s/hooks/%s", workspace, uuid)
+}
+
+// GetForWorkspace fetches the workspace hook with the given uuid.
+func (s *HooksService) GetForWorkspace(ctx context.Context, workspace, uuid string) (*Hook, error) {
+	var hook Hook
+	_, err := s.client.doJSON(ctx, "GET", workspaceHookEndpoint(workspace, uuid), nil, &hook)
+	if err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// CreateForWorkspace adds a hook to a workspace, it is called for the events of every repository of it.
+func (s *HooksService) CreateForWorkspace(ctx context.Context, workspace string, hook *Hook) (*Hook, error) {
+	var created Hook
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/workspaces/%s/hooks", workspace), hook, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateForWorkspace changes the workspace hook with the given uuid.
+func (s *HooksService) UpdateForWorkspace(ctx context.Context, workspace, uuid string, hook *Hook) (*Hook, error) {
+	var updated Hook
+	_, err := s.client.doJSON(ctx, "PUT", workspaceHookEndpoint(workspace, uuid), hook, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteForWorkspace removes the workspace hook with the given uuid.
+func (s *HooksService) DeleteForWorkspace(ctx context.Context, workspace, uuid string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", workspaceHookEndpoint(workspace, uuid), nil, nil)
+	return err
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+)
+
+// IssuesService talks to the issue tracker endpoints of a repository.
+type IssuesService service
+
+// IssueContent is the text of an issue, only the markdown in Raw is sent, bitbucket renders the rest.
+type IssueContent struct {
+	Raw string `json:"raw"`
+}
+
+// Issue is an issue of the issue tracker of a repository. Assignee is always sent, no assignee unassigns
+// the issue.
+type Issue struct {
+	ID       int           `json:"id,omitempty"`
+	Title    string        `json:"title"`
+	Content  *IssueContent `json:"content,omitempty"`
+	Kind     string        `json:"kind,omitempty"`
+	Priority string        `json:"priority,omitempty"`
+	State    string        `json:"state,omitempty"`
+	Assignee *User         `json:"assignee"`
+}
+
+func issueEndpoint(owner, slug, id string) string {
+	return apiPath("2.0/repositories/%s/%s/issues/%s", owner, slug, id)
+}
+
+// Get fetches the issue with the given id.
+func (s *IssuesService) Get(ctx context.Context, owner, slug, id string) (*Issue, error) {
+	var issue Issue
+	_, err := s.client.doJSON(ctx, "GET", issueEndpoint(owner, slug, id), nil, &issue)
+	if err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// Create opens an issue, the issue tracker of the repository has to be turned on.
+func (s *IssuesService) Create(ctx context.Context, owner, slug string, issue *Issue) (*Issue, error) {
+	var created Issue
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/issues", owner, slug), issue, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the issue with the given id.
+func (s *IssuesService) Update(ctx context.Context, owner, slug, id string, issue *Issue) (*Issue, error) {
+	var updated Issue
+	_, err := s.client.doJSON(ctx, "PUT", issueEndpoint(owner, slug, id), issue, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the issue with the given id.
+func (s *IssuesService) Delete(ctx context.Context, owner, slug, id string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", issueEndpoint(owner, slug, id), nil, nil)
+	return err
+}
+
+// IssueTrackerField is a component, milestone or version of an issue tracker, all of them only have a
+// name. The 2.0 API only serves them, they are created, renamed and deleted with the 1.0 API.
+type IssueTrackerField struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// GetField fetches the component, milestone or version with the given id. kind is the collection of the
+// field, one of components, milestones or versions.
+func (s *IssuesService) GetField(ctx context.Context, owner, slug, kind, id string) (*IssueTrackerField, error) {
+	var field IssueTrackerField
+	_, err := s.client.doJSON(ctx, "GET", apiPath("2.0/repositories/%s/%s/%s/%s", owner, slug, kind, id), nil, &field)
+	if err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// CreateField adds a component, milestone or version named name to the issue tracker of a repository.
+func (s *IssuesService) CreateField(ctx context.Context, owner, slug, kind, name string) (*IssueTrackerField, error) {
+	return s.sendField(ctx, owner, slug, kind, "", name)
+}
+
+// UpdateField renames the component, milestone or version with the given id.
+func (s *IssuesService) UpdateField(ctx context.Context, owner, slug, kind, id, name string) (*IssueTrackerField, error) {
+	return s.sendField(ctx, owner, slug, kind, id, name)
+}
+
+// sendField creates a field when id is empty and renames the one with the given id otherwise. The 1.0 API
+// only takes the name as a form.
+func (s *IssuesService) sendField(ctx context.Context, owner, slug, kind, id, name string) (*IssueTrackerField, error) {
+	method, endpoint := "POST", apiPath("1.0/repositories/%s/%s/issues/%s", owner, slug, kind)
+	if id != "" {
+		method, endpoint = "PUT", apiPath("1.0/repositories/%s/%s/issues/%s/%s", owner, slug, kind, id)
+	}
+	form := url.Values{"name": []string{name}}
+
+	resp, err := s.client.do(ctx, method, endpoint, []byte(form.Encode()), "application/x-www-form-urlencoded")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var sent IssueTrackerField
+	if err := decodeJSON(endpoint, body, &sent); err != nil {
+		return nil, err
+	}
+	return &sent, nil
+}
+
+// DeleteField removes the component, milestone or version with the given id, issues using it lose it.
+func (s *IssuesService) DeleteField(ctx context.Context, owner, slug, kind, id string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", apiPath("1.0/repositories/%s/%s/issues/%s/%s", owner, slug, kind, id), nil, nil)
+	return err
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIssuesUpdateField(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/1.0/repositories/gob/illusions/issues/components/42" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" || r.FormValue("name") != "Tricks" {
+			t.Fatalf("expected the name as a form, got %s %q", r.Header.Get("Content-Type"), r.FormValue("name"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"Tricks","id":42}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	component, err := client.Issues.UpdateField(context.Background(), "gob", "illusions", "components", "42", "Tricks")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if component.ID != 42 || component.Name != "Tricks" {
+		t.Fatalf("unexpected component %+v", component)
+	}
+}
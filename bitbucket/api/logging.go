@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/logging"
+)
+
+const redacted = "[REDACTED]"
+
+// redactedHeaders never show up in the logs, they carry the credentials.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// redactedFields are JSON fields that can hold secrets, like the value of a secured variable.
+var redactedFields = map[string]bool{
+	"value":         true,
+	"secret":        true,
+	"password":      true,
+	"private_key":   true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+}
+
+// logRequest dumps a request when TF_LOG is DEBUG or TRACE
+func logRequest(req *http.Request) {
+	if !logging.IsDebugOrHigher() {
+		return
+	}
+
+	var body []byte
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+		// Uploaded files can hold anything, only say how big they are.
+		body = []byte(fmt.Sprintf("[multipart form of %d bytes]", req.ContentLength))
+	} else if req.GetBody != nil {
+		if reader, err := req.GetBody(); err == nil {
+			body, _ = ioutil.ReadAll(reader)
+		}
+	}
+
+	log.Printf("[DEBUG] Bitbucket API Request: %s %s\n%s%s", req.Method, req.URL, formatHeaders(req.Header), redactBody(body))
+}
+
+// logResponse dumps a response when TF_LOG is DEBUG or TRACE
+func logResponse(req *http.Request, resp *http.Response, body []byte, duration time.Duration) {
+	if !logging.IsDebugOrHigher() {
+		return
+	}
+
+	log.Printf("[DEBUG] Bitbucket API Response: %s %s %s in %s\n%s%s", req.Method, req.URL, resp.Status, duration, formatHeaders(resp.Header), redactBody(body))
+}
+
+func formatHeaders(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		value := strings.Join(headers[name], ", ")
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		fmt.Fprintf(&b, "%s: %s\n", name, value)
+	}
+
+	return b.String()
+}
+
+// redactBody hides the values of redactedFields anywhere in a JSON body. Bodies that aren't JSON, like
+// HTML error pages, are logged as they are.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return string(body)
+	}
+
+	redactedBody, err := json.Marshal(redactValue(data))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redactedBody)
+}
+
+func redactValue(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if redactedFields[strings.ToLower(key)] {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// FormFile is a file sent as part of a multipart form, Field is the name of the form field it is sent as.
+type FormFile struct {
+	Field    string
+	Filename string
+	Content  []byte
+}
+
+// PostForm is a helper to send a multipart/form-data POST, needed by the endpoints that take file
+// uploads like commits to `src` or downloads. Fields are sent as plain form values, a field with several
+// values is repeated. The form is built in memory so the request can be retried like any other.
+func (c *Client) PostForm(ctx context.Context, endpoint string, fields url.Values, files []FormFile) (*http.Response, error) {
+	return c.sendForm(ctx, "POST", endpoint, fields, files)
+}
+
+// PutForm is PostForm for the endpoints that take file uploads to update, like snippets.
+func (c *Client) PutForm(ctx context.Context, endpoint string, fields url.Values, files []FormFile) (*http.Response, error) {
+	return c.sendForm(ctx, "PUT", endpoint, fields, files)
+}
+
+func (c *Client) sendForm(ctx context.Context, method, endpoint string, fields url.Values, files []FormFile) (*http.Response, error) {
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range fields[name] {
+			if err := writer.WriteField(name, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.Field, file.Filename)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := part.Write(file.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return c.do(ctx, method, endpoint, form.Bytes(), writer.FormDataContentType())
+}
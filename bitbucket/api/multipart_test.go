@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestClientPostForm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if r.FormValue("message") != "Add the trick" || r.FormValue("branch") != "main" {
+			t.Fatalf("expected the fields to be sent, got %v", r.MultipartForm.Value)
+		}
+
+		file, header, err := r.FormFile("files")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		defer file.Close()
+
+		content, _ := ioutil.ReadAll(file)
+		if header.Filename != "trick.txt" || string(content) != "now you see me" {
+			t.Fatalf("expected the file to be sent, got %s with %q", header.Filename, content)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	fields := url.Values{"message": []string{"Add the trick"}, "branch": []string{"main"}}
+	files := []FormFile{{Field: "files", Filename: "trick.txt", Content: []byte("now you see me")}}
+
+	if _, err := client.PostForm(context.Background(), "2.0/repositories/gob/illusions/src", fields, files); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
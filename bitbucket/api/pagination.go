@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// errStopPaging is returned by the fn of listPages once it found what it was looking for, the remaining
+// pages are not requested.
+var errStopPaging = errors.New("stop paging")
+
+// page is the envelope bitbucket wraps every paginated collection in
+type page struct {
+	Values json.RawMessage `json:"values"`
+	Next   string          `json:"next,omitempty"`
+}
+
+// listPages calls fn with the values of every page of the collection at endpoint. It follows the next
+// link of each page until bitbucket stops handing one out, so callers see the whole collection. Pages
+// requested by several callers at the same time are only fetched once.
+func (c *Client) listPages(ctx context.Context, endpoint string, fn func(values json.RawMessage) error) error {
+	endpoint = c.withPageLen(endpoint)
+
+	for endpoint != "" {
+		body, err := c.getShared(ctx, endpoint)
+		if err != nil {
+			return err
+		}
+
+		var p page
+		if len(body) > 0 {
+			if err := decodeJSON(endpoint, body, &p); err != nil {
+				return err
+			}
+		}
+
+		if len(p.Values) > 0 {
+			if err := fn(p.Values); err == errStopPaging {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+
+		next, err := c.nextEndpoint(p.Next)
+		if err != nil {
+			return err
+		}
+		endpoint = next
+	}
+
+	return nil
+}
+
+// nextEndpoint turns the absolute next link of a page back into an endpoint relative to the base url,
+// so the next page is requested the same way as the first one.
+func (c *Client) nextEndpoint(next string) (string, error) {
+	if next == "" {
+		return "", nil
+	}
+
+	nextURL, err := url.Parse(next)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL, err := url.Parse(c.baseURL())
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := strings.TrimPrefix(nextURL.EscapedPath(), strings.TrimSuffix(baseURL.EscapedPath(), "/"))
+	endpoint = strings.TrimPrefix(endpoint, "/")
+
+	if nextURL.RawQuery != "" {
+		endpoint = endpoint + "?" + nextURL.RawQuery
+	}
+
+	return endpoint, nil
+}
+
+// legacyPage is how the 1.0 endpoints, like groups and group members, page a collection. They take
+// start and limit parameters and report either the count of the whole collection or whether this is
+// the last page, there is no next link.
+type legacyPage struct {
+	Values        json.RawMessage `json:"values"`
+	Start         int             `json:"start"`
+	Count         *int            `json:"count,omitempty"`
+	IsLastPage    *bool           `json:"isLastPage,omitempty"`
+	NextPageStart *int            `json:"nextPageStart,omitempty"`
+}
+
+// listLegacyPages calls fn with the values of every page of a 1.0 collection at endpoint, like the members
+// of a group. Most of them answer with a plain array of everything, which is passed to fn as a single
+// page, so every 1.0 collection is listed through it.
+func (c *Client) listLegacyPages(ctx context.Context, endpoint string, fn func(values json.RawMessage) error) error {
+	start := 0
+
+	for {
+		pageEndpoint := legacyPageEndpoint(endpoint, start, c.PageLen)
+
+		body, err := c.getShared(ctx, pageEndpoint)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.TrimSpace(body)
+		if len(body) == 0 {
+			return nil
+		}
+
+		if body[0] == '[' {
+			return fn(body)
+		}
+
+		var p legacyPage
+		if err := decodeJSON(pageEndpoint, body, &p); err != nil {
+			return err
+		}
+
+		var values []json.RawMessage
+		if len(p.Values) > 0 {
+			if err := decodeJSON(pageEndpoint, p.Values, &values); err != nil {
+				return err
+			}
+
+			if err := fn(p.Values); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case len(values) == 0:
+			return nil
+		case p.NextPageStart != nil && (p.IsLastPage == nil || !*p.IsLastPage):
+			start = *p.NextPageStart
+		case p.IsLastPage == nil && p.Count != nil && p.Start+len(values) < *p.Count:
+			start = p.Start + len(values)
+		default:
+			return nil
+		}
+	}
+}
+
+// legacyPageEndpoint asks a 1.0 endpoint for the page starting at start, limit is left to bitbucket
+// unless the pagelen of the provider is set.
+func legacyPageEndpoint(endpoint string, start, limit int) string {
+	var params []string
+	if start > 0 {
+		params = append(params, fmt.Sprintf("start=%d", start))
+	}
+	if limit > 0 {
+		params = append(params, fmt.Sprintf("limit=%d", limit))
+	}
+
+	if len(params) == 0 {
+		return endpoint
+	}
+
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	return endpoint + separator + strings.Join(params, "&")
+}
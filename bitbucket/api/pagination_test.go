@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientListPages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gateway/2.0/repositories/gob/illusions/default-reviewers" {
+			t.Fatalf("unexpected request for %s", r.URL.Path)
+		}
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"values": [{"uuid": "{gob}"}], "next": "%s/gateway/2.0/repositories/gob/illusions/default-reviewers?page=2&pagelen=1"}`, server.URL)
+		case "2":
+			fmt.Fprint(w, `{"values": [{"uuid": "{buster}"}]}`)
+		default:
+			t.Fatalf("unexpected page %s", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL + "/gateway/"
+	client.PageLen = 1
+
+	reviewers, err := client.DefaultReviewers.List(context.Background(), "gob", "illusions")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(reviewers) != 2 || reviewers[0].UUID != "{gob}" || reviewers[1].UUID != "{buster}" {
+		t.Fatalf("expected the reviewers of both pages, got %+v", reviewers)
+	}
+}
+
+func TestClientListLegacyPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/1.0/groups/gob":
+			fmt.Fprint(w, `[{"slug": "magicians"}, {"slug": "bluths"}]`)
+		case "/1.0/group-privileges/gob/illusions":
+			if r.URL.Query().Get("limit") != "1" {
+				t.Fatalf("expected the pagelen to be sent as the limit, got %s", r.URL.RawQuery)
+			}
+
+			switch r.URL.Query().Get("start") {
+			case "":
+				fmt.Fprint(w, `{"values": [{"slug": "magicians"}], "start": 0, "count": 2}`)
+			case "1":
+				fmt.Fprint(w, `{"values": [{"slug": "bluths"}], "start": 1, "count": 2}`)
+			default:
+				t.Fatalf("unexpected start %s", r.URL.Query().Get("start"))
+			}
+		default:
+			t.Fatalf("unexpected request for %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+	client.PageLen = 1
+
+	for _, endpoint := range []string{"1.0/groups/gob", "1.0/group-privileges/gob/illusions"} {
+		var groups []Group
+		err := client.listLegacyPages(context.Background(), endpoint, func(values json.RawMessage) error {
+			var page []Group
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			groups = append(groups, page...)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if len(groups) != 2 || groups[1].Slug != "bluths" {
+			t.Fatalf("expected every group of %s, got %+v", endpoint, groups)
+		}
+	}
+}
+
+func TestDeploymentVariablesGet(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"values": [{"key": "COUNTRY", "uuid": "{country}"}], "next": "%s/2.0/repositories/gob/illusions/deployments_config/environments/%%7Bstaging%%7D/variables?page=2"}`, server.URL)
+		case "2":
+			fmt.Fprintf(w, `{"values": [{"key": "PASSWORD", "uuid": "{password}"}], "next": "%s/2.0/repositories/gob/illusions/deployments_config/environments/%%7Bstaging%%7D/variables?page=3"}`, server.URL)
+		case "3":
+			fmt.Fprint(w, `{"values": [{"key": "TOKEN", "uuid": "{token}"}]}`)
+		default:
+			t.Fatalf("unexpected page %s", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	variable, err := client.DeploymentVariables.Get(context.Background(), "gob", "illusions", "{staging}", "{password}")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if variable.Key != "PASSWORD" {
+		t.Fatalf("expected the variable of the second page, got %+v", variable)
+	}
+
+	if _, err := client.DeploymentVariables.Get(context.Background(), "gob", "illusions", "{staging}", "{missing}"); !IsNotFound(err) {
+		t.Fatalf("expected a variable on no page to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// apiPath builds an endpoint from format with every argument path escaped. Slugs, keys, usernames and
+// uuids, which bitbucket wraps in braces, always end up as exactly one segment of the path this way.
+func apiPath(format string, args ...string) string {
+	escaped := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		escaped = append(escaped, url.PathEscape(arg))
+	}
+
+	return fmt.Sprintf(format, escaped...)
+}
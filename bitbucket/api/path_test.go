@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientEscapesPaths(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := "/2.0/repositories/gob/illusions/deployments_config/environments/%7Benv%7D/variables/%7Bvar%2Fiable%7D"
+		if r.URL.EscapedPath() != expected {
+			t.Fatalf("expected %s, got %s", expected, r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	if err := client.DeploymentVariables.Delete(context.Background(), "gob", "illusions", "{env}", "{var/iable}"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+)
+
+// PipelineSchedulesService talks to the pipeline schedule endpoints of a repository.
+type PipelineSchedulesService service
+
+// PipelineSchedule runs the pipeline of Target whenever CronPattern matches. Bitbucket only lets Enabled
+// change once a schedule exists.
+type PipelineSchedule struct {
+	Type        string          `json:"type,omitempty"`
+	UUID        string          `json:"uuid,omitempty"`
+	Enabled     bool            `json:"enabled"`
+	CronPattern string          `json:"cron_pattern,omitempty"`
+	Target      *PipelineTarget `json:"target,omitempty"`
+}
+
+// PipelineTarget is the branch a schedule runs on and which of its pipelines.
+type PipelineTarget struct {
+	Type     string            `json:"type,omitempty"`
+	RefType  string            `json:"ref_type,omitempty"`
+	RefName  string            `json:"ref_name,omitempty"`
+	Selector *PipelineSelector `json:"selector,omitempty"`
+}
+
+// PipelineSelector picks a pipeline of the bitbucket-pipelines.yml, `branches` ones by the branch pattern
+// and `custom` ones by their name.
+type PipelineSelector struct {
+	Type    string `json:"type,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+}
+
+func pipelineScheduleEndpoint(owner, slug, uuid string) string {
+	return apiPath("2.0/repositories/%s/%s/pipelines_config/schedules/%s", owner, slug, uuid)
+}
+
+// Get fetches the pipeline schedule with the given uuid.
+func (s *PipelineSchedulesService) Get(ctx context.Context, owner, slug, uuid string) (*PipelineSchedule, error) {
+	var schedule PipelineSchedule
+	_, err := s.client.doJSON(ctx, "GET", pipelineScheduleEndpoint(owner, slug, uuid), nil, &schedule)
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// Create adds a pipeline schedule to a repository.
+func (s *PipelineSchedulesService) Create(ctx context.Context, owner, slug string, schedule *PipelineSchedule) (*PipelineSchedule, error) {
+	var created PipelineSchedule
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/pipelines_config/schedules/", owner, slug), schedule, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update turns the pipeline schedule with the given uuid on or off.
+func (s *PipelineSchedulesService) Update(ctx context.Context, owner, slug, uuid string, enabled bool) (*PipelineSchedule, error) {
+	var updated PipelineSchedule
+	_, err := s.client.doJSON(ctx, "PUT", pipelineScheduleEndpoint(owner, slug, uuid), &PipelineSchedule{Type: "pipeline_schedule", Enabled: enabled}, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the pipeline schedule with the given uuid.
+func (s *PipelineSchedulesService) Delete(ctx context.Context, owner, slug, uuid string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", pipelineScheduleEndpoint(owner, slug, uuid), nil, nil)
+	return err
+}
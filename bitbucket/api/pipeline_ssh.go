@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+)
+
+// PipelineSSHService talks to the ssh settings pipelines of a repository use.
+type PipelineSSHService service
+
+// PipelineSSHKeyPair is the identity of the builds of a repository. Bitbucket never serves the private key
+// back, only the public one.
+type PipelineSSHKeyPair struct {
+	PrivateKey string `json:"private_key,omitempty"`
+	PublicKey  string `json:"public_key,omitempty"`
+}
+
+func pipelineSSHKeyPairEndpoint(owner, slug string) string {
+	return apiPath("2.0/repositories/%s/%s/pipelines_config/ssh/key_pair", owner, slug)
+}
+
+// GetKeyPair fetches the public key of the key pair of a repository.
+func (s *PipelineSSHService) GetKeyPair(ctx context.Context, owner, slug string) (*PipelineSSHKeyPair, error) {
+	var pair PipelineSSHKeyPair
+	_, err := s.client.doJSON(ctx, "GET", pipelineSSHKeyPairEndpoint(owner, slug), nil, &pair)
+	if err != nil {
+		return nil, err
+	}
+	return &pair, nil
+}
+
+// UpdateKeyPair sets the key pair of a repository, replacing the one it had.
+func (s *PipelineSSHService) UpdateKeyPair(ctx context.Context, owner, slug string, pair *PipelineSSHKeyPair) (*PipelineSSHKeyPair, error) {
+	var updated PipelineSSHKeyPair
+	_, err := s.client.doJSON(ctx, "PUT", pipelineSSHKeyPairEndpoint(owner, slug), pair, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteKeyPair removes the key pair of a repository.
+func (s *PipelineSSHService) DeleteKeyPair(ctx context.Context, owner, slug string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", pipelineSSHKeyPairEndpoint(owner, slug), nil, nil)
+	return err
+}
+
+// PipelineKnownHost is a host the builds of a repository trust, along with its public key.
+type PipelineKnownHost struct {
+	UUID      string                `json:"uuid,omitempty"`
+	Hostname  string                `json:"hostname,omitempty"`
+	PublicKey *PipelineKnownHostKey `json:"public_key,omitempty"`
+}
+
+// PipelineKnownHostKey is the public key of a known host, bitbucket computes the fingerprints.
+type PipelineKnownHostKey struct {
+	KeyType           string `json:"key_type,omitempty"`
+	Key               string `json:"key,omitempty"`
+	MD5Fingerprint    string `json:"md5_fingerprint,omitempty"`
+	SHA256Fingerprint string `json:"sha256_fingerprint,omitempty"`
+}
+
+func pipelineKnownHostEndpoint(owner, slug, uuid string) string {
+	return apiPath("2.0/repositories/%s/%s/pipelines_config/ssh/known_hosts/%s", owner, slug, uuid)
+}
+
+// GetKnownHost fetches the known host of a repository with the given uuid.
+func (s *PipelineSSHService) GetKnownHost(ctx context.Context, owner, slug, uuid string) (*PipelineKnownHost, error) {
+	var host PipelineKnownHost
+	_, err := s.client.doJSON(ctx, "GET", pipelineKnownHostEndpoint(owner, slug, uuid), nil, &host)
+	if err != nil {
+		return nil, err
+	}
+	return &host, nil
+}
+
+// CreateKnownHost adds a known host to a repository.
+func (s *PipelineSSHService) CreateKnownHost(ctx context.Context, owner, slug string, host *PipelineKnownHost) (*PipelineKnownHost, error) {
+	var created PipelineKnownHost
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/pipelines_config/ssh/known_hosts/", owner, slug), host, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateKnownHost changes the known host of a repository with the given uuid.
+func (s *PipelineSSHService) UpdateKnownHost(ctx context.Context, owner, slug, uuid string, host *PipelineKnownHost) (*PipelineKnownHost, error) {
+	var updated PipelineKnownHost
+	_, err := s.client.doJSON(ctx, "PUT", pipelineKnownHostEndpoint(owner, slug, uuid), host, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteKnownHost removes the known host of a repository with the given uuid.
+func (s *PipelineSSHService) DeleteKnownHost(ctx context.Context, owner, slug, uuid string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", pipelineKnownHostEndpoint(owner, slug, uuid), nil, nil)
+	return err
+}
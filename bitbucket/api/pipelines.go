@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// PipelinesService talks to the endpoints that run the pipelines of a repository.
+type PipelinesService service
+
+// PipelineVariable is a variable a pipeline is run with, on top of the ones of the repository,
+// workspace and deployment.
+type PipelineVariable struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Secured bool   `json:"secured"`
+}
+
+// PipelineState is where a pipeline is at, PENDING, IN_PROGRESS or COMPLETED. Only a completed pipeline
+// has a Result like SUCCESSFUL, FAILED, ERROR or STOPPED.
+type PipelineState struct {
+	Name   string `json:"name"`
+	Result *struct {
+		Name string `json:"name"`
+	} `json:"result,omitempty"`
+}
+
+// Pipeline is a run of a pipeline of a repository.
+type Pipeline struct {
+	UUID        string             `json:"uuid,omitempty"`
+	BuildNumber int                `json:"build_number,omitempty"`
+	Target      *PipelineTarget    `json:"target,omitempty"`
+	Variables   []PipelineVariable `json:"variables,omitempty"`
+	State       *PipelineState     `json:"state,omitempty"`
+}
+
+// Get fetches the pipeline with the given uuid.
+func (s *PipelinesService) Get(ctx context.Context, owner, slug, uuid string) (*Pipeline, error) {
+	var pipeline Pipeline
+	_, err := s.client.doJSON(ctx, "GET", apiPath("2.0/repositories/%s/%s/pipelines/%s", owner, slug, uuid), nil, &pipeline)
+	if err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+// Run starts the pipeline of Target with Variables, pipelines have to be enabled for the repository.
+// Bitbucket answers as soon as the pipeline is queued.
+func (s *PipelinesService) Run(ctx context.Context, owner, slug string, pipeline *Pipeline) (*Pipeline, error) {
+	var started Pipeline
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/pipelines/", owner, slug), pipeline, &started)
+	if err != nil {
+		return nil, err
+	}
+	return &started, nil
+}
+
+// PipelineCache is a dependency cache the pipelines of a repository share, caches are named in the
+// bitbucket-pipelines.yml.
+type PipelineCache struct {
+	UUID          string `json:"uuid"`
+	Name          string `json:"name"`
+	Path          string `json:"path,omitempty"`
+	FileSizeBytes int64  `json:"file_size_bytes,omitempty"`
+	CreatedOn     string `json:"created_on,omitempty"`
+}
+
+// ListCaches fetches every cache of the pipelines of a repository.
+func (s *PipelinesService) ListCaches(ctx context.Context, owner, slug string) ([]PipelineCache, error) {
+	var caches []PipelineCache
+	endpoint := apiPath("2.0/repositories/%s/%s/pipelines-config/caches", owner, slug)
+
+	err := s.client.listPages(ctx, endpoint, func(values json.RawMessage) error {
+		var page []PipelineCache
+		if err := decodeJSON(endpoint, values, &page); err != nil {
+			return err
+		}
+		caches = append(caches, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return caches, nil
+}
+
+// DeleteCache removes the cache with the given uuid, the next pipeline using it fills it again.
+func (s *PipelinesService) DeleteCache(ctx context.Context, owner, slug, uuid string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", apiPath("2.0/repositories/%s/%s/pipelines-config/caches/%s", owner, slug, uuid), nil, nil)
+	return err
+}
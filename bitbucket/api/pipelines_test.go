@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPipelinesRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/2.0/repositories/gob/illusions/pipelines/" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"target":{"type":"pipeline_ref_target","ref_type":"branch","ref_name":"master","selector":{"type":"custom","pattern":"bootstrap"}},"variables":[{"key":"STAGE","value":"test","secured":false}]}` {
+			t.Fatalf("unexpected body %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid":"{5f1e0ab}","build_number":42,"state":{"name":"PENDING"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	pipeline, err := client.Pipelines.Run(context.Background(), "gob", "illusions", &Pipeline{
+		Target: &PipelineTarget{
+			Type:     "pipeline_ref_target",
+			RefType:  "branch",
+			RefName:  "master",
+			Selector: &PipelineSelector{Type: "custom", Pattern: "bootstrap"},
+		},
+		Variables: []PipelineVariable{{Key: "STAGE", Value: "test"}},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if pipeline.BuildNumber != 42 || pipeline.State == nil || pipeline.State.Name != "PENDING" || pipeline.State.Result != nil {
+		t.Fatalf("unexpected pipeline %+v", pipeline)
+	}
+}
+
+func TestPipelinesListCaches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/2.0/repositories/gob/illusions/pipelines-config/caches" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[{"uuid":"{5f1e0ab}","name":"node","path":"node_modules"},{"uuid":"{0ab5f1e}","name":"docker"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	caches, err := client.Pipelines.ListCaches(context.Background(), "gob", "illusions")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(caches) != 2 || caches[0].Name != "node" || caches[1].UUID != "{0ab5f1e}" {
+		t.Fatalf("unexpected caches %+v", caches)
+	}
+}
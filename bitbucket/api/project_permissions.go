@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+)
+
+// ProjectPermissionsService talks to the explicit permission endpoints of a project.
+type ProjectPermissionsService service
+
+// ProjectPermission is the permission, read, write, create-repo or admin, a group or user has on a project
+// and its repositories.
+type ProjectPermission struct {
+	Permission string          `json:"permission"`
+	Group      *WorkspaceGroup `json:"group,omitempty"`
+	User       *User           `json:"user,omitempty"`
+}
+
+func projectGroupPermissionEndpoint(owner, key, group string) string {
+	return apiPath("2.0/workspaces/%s/projects/%s/permissions-config/groups/%s", owner, key, group)
+}
+
+// GetGroup fetches the permission of the group with the given slug on a project.
+func (s *ProjectPermissionsService) GetGroup(ctx context.Context, owner, key, group string) (*ProjectPermission, error) {
+	var permission ProjectPermission
+	_, err := s.client.doJSON(ctx, "GET", projectGroupPermissionEndpoint(owner, key, group), nil, &permission)
+	if err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// UpdateGroup grants the group with the given slug permission on a project, replacing the one it had.
+func (s *ProjectPermissionsService) UpdateGroup(ctx context.Context, owner, key, group, permission string) error {
+	_, err := s.client.doJSON(ctx, "PUT", projectGroupPermissionEndpoint(owner, key, group), &ProjectPermission{Permission: permission}, nil)
+	return err
+}
+
+// DeleteGroup takes the explicit permission of the group with the given slug on a project away.
+func (s *ProjectPermissionsService) DeleteGroup(ctx context.Context, owner, key, group string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", projectGroupPermissionEndpoint(owner, key, group), nil, nil)
+	return err
+}
+
+func projectUserPermissionEndpoint(owner, key, user string) string {
+	return apiPath("2.0/workspaces/%s/projects/%s/permissions-config/users/%s", owner, key, user)
+}
+
+// GetUser fetches the explicit permission of the user with the given uuid or account id on a project.
+// A permission the user only has through a group or the workspace is not returned.
+func (s *ProjectPermissionsService) GetUser(ctx context.Context, owner, key, user string) (*ProjectPermission, error) {
+	var permission ProjectPermission
+	_, err := s.client.doJSON(ctx, "GET", projectUserPermissionEndpoint(owner, key, user), nil, &permission)
+	if err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// UpdateUser grants the user with the given uuid or account id permission on a project, replacing the
+// one they had.
+func (s *ProjectPermissionsService) UpdateUser(ctx context.Context, owner, key, user, permission string) error {
+	_, err := s.client.doJSON(ctx, "PUT", projectUserPermissionEndpoint(owner, key, user), &ProjectPermission{Permission: permission}, nil)
+	return err
+}
+
+// DeleteUser takes the explicit permission of the user with the given uuid or account id on a project
+// away.
+func (s *ProjectPermissionsService) DeleteUser(ctx context.Context, owner, key, user string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", projectUserPermissionEndpoint(owner, key, user), nil, nil)
+	return err
+}
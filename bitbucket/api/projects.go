@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+)
+
+// ProjectsService talks to the project endpoints of a workspace.
+type ProjectsService service
+
+// Project is the project data we need to send to create a project on the bitbucket api. The settings that
+// can be turned off or cleared are always sent.
+type Project struct {
+	Key         string        `json:"key,omitempty"`
+	IsPrivate   bool          `json:"is_private"`
+	Owner       string        `json:"owner.username,omitempty"`
+	Description string        `json:"description"`
+	Name        string        `json:"name,omitempty"`
+	UUID        string        `json:"uuid,omitempty"`
+	Links       *ProjectLinks `json:"links,omitempty"`
+}
+
+// ProjectLinks are the links of a project, only the avatar can be changed. It is sent as a url or a data
+// uri of the image and always comes back as a url.
+type ProjectLinks struct {
+	Avatar struct {
+		Href string `json:"href,omitempty"`
+	} `json:"avatar"`
+}
+
+func projectEndpoint(owner, key string) string {
+	return apiPath("2.0/workspaces/%s/projects/%s", owner, key)
+}
+
+// Get fetches the project with the given key.
+func (s *ProjectsService) Get(ctx context.Context, owner, key string) (*Project, error) {
+	var project Project
+	_, err := s.client.doJSON(ctx, "GET", projectEndpoint(owner, key), nil, &project)
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// Create creates a project in the workspace owner.
+func (s *ProjectsService) Create(ctx context.Context, owner string, project *Project) (*Project, error) {
+	var created Project
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/workspaces/%s/projects/", owner), project, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the project with the given key.
+func (s *ProjectsService) Update(ctx context.Context, owner, key string, project *Project) (*Project, error) {
+	var updated Project
+	_, err := s.client.doJSON(ctx, "PUT", projectEndpoint(owner, key), project, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete deletes the project with the given key.
+func (s *ProjectsService) Delete(ctx context.Context, owner, key string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", projectEndpoint(owner, key), nil, nil)
+	return err
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+)
+
+// PullRequestsService talks to the pull request endpoints of a repository.
+type PullRequestsService service
+
+// PullRequestBranch is the source or destination of a pull request.
+type PullRequestBranch struct {
+	Branch *Ref `json:"branch"`
+}
+
+// PullRequest is a pull request of a repository. Reviewers are always sent, an update replaces them.
+type PullRequest struct {
+	ID                int                `json:"id,omitempty"`
+	Title             string             `json:"title"`
+	Description       string             `json:"description"`
+	State             string             `json:"state,omitempty"`
+	Source            *PullRequestBranch `json:"source,omitempty"`
+	Destination       *PullRequestBranch `json:"destination,omitempty"`
+	Reviewers         []Reviewer         `json:"reviewers"`
+	CloseSourceBranch bool               `json:"close_source_branch"`
+}
+
+func pullRequestEndpoint(owner, slug, id string) string {
+	return apiPath("2.0/repositories/%s/%s/pullrequests/%s", owner, slug, id)
+}
+
+// Get fetches the pull request with the given id.
+func (s *PullRequestsService) Get(ctx context.Context, owner, slug, id string) (*PullRequest, error) {
+	var pr PullRequest
+	_, err := s.client.doJSON(ctx, "GET", pullRequestEndpoint(owner, slug, id), nil, &pr)
+	if err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// Create opens a pull request, without a destination it targets the main branch of the repository.
+func (s *PullRequestsService) Create(ctx context.Context, owner, slug string, pr *PullRequest) (*PullRequest, error) {
+	var created PullRequest
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/pullrequests", owner, slug), pr, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the open pull request with the given id, its source can't be changed.
+func (s *PullRequestsService) Update(ctx context.Context, owner, slug, id string, pr *PullRequest) (*PullRequest, error) {
+	var updated PullRequest
+	_, err := s.client.doJSON(ctx, "PUT", pullRequestEndpoint(owner, slug, id), pr, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Decline declines the open pull request with the given id, bitbucket keeps declined pull requests.
+func (s *PullRequestsService) Decline(ctx context.Context, owner, slug, id string) error {
+	_, err := s.client.doJSON(ctx, "POST", pullRequestEndpoint(owner, slug, id)+"/decline", nil, nil)
+	return err
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPullRequestsCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/2.0/repositories/gob/illusions/pullrequests" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"title":"Add the trick","description":"","source":{"branch":{"name":"trick"}},"reviewers":[],"close_source_branch":true}` {
+			t.Fatalf("unexpected body %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":7,"title":"Add the trick","state":"OPEN","destination":{"branch":{"name":"master"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	pr, err := client.PullRequests.Create(context.Background(), "gob", "illusions", &PullRequest{
+		Title:             "Add the trick",
+		Source:            &PullRequestBranch{Branch: &Ref{Name: "trick"}},
+		Reviewers:         []Reviewer{},
+		CloseSourceBranch: true,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if pr.ID != 7 || pr.Destination == nil || pr.Destination.Branch.Name != "master" {
+		t.Fatalf("unexpected pull request %+v", pr)
+	}
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(20)
+
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		limiter.Wait(context.Background())
+	}
+
+	// The first 20 requests use up the burst, the other 10 have to wait for new tokens.
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected the limiter to slow down requests, 30 requests took %s", elapsed)
+	}
+}
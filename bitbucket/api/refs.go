@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+)
+
+// RefsService talks to the branch and tag endpoints of a repository.
+type RefsService service
+
+// RefTarget is the commit a ref points to. When creating a ref Hash may also be the name of a branch or
+// tag, bitbucket resolves it to its commit.
+type RefTarget struct {
+	Hash string `json:"hash"`
+}
+
+// Ref is a branch or a tag of a repository. Only annotated tags have a Message, a tag created with one
+// is annotated. Only branches have merge strategies, they are set in the settings of the repository and
+// only ever read.
+type Ref struct {
+	Name                 string     `json:"name"`
+	Target               *RefTarget `json:"target,omitempty"`
+	Message              string     `json:"message,omitempty"`
+	MergeStrategies      []string   `json:"merge_strategies,omitempty"`
+	DefaultMergeStrategy string     `json:"default_merge_strategy,omitempty"`
+}
+
+func branchEndpoint(owner, slug, name string) string {
+	return apiPath("2.0/repositories/%s/%s/refs/branches/%s", owner, slug, name)
+}
+
+// GetBranch fetches the branch with the given name, which may contain slashes.
+func (s *RefsService) GetBranch(ctx context.Context, owner, slug, name string) (*Ref, error) {
+	var branch Ref
+	_, err := s.client.doJSON(ctx, "GET", branchEndpoint(owner, slug, name), nil, &branch)
+	if err != nil {
+		return nil, err
+	}
+	return &branch, nil
+}
+
+// CreateBranch creates a branch with the given name pointing at target, a commit hash or the name of
+// another branch.
+func (s *RefsService) CreateBranch(ctx context.Context, owner, slug, name, target string) (*Ref, error) {
+	var created Ref
+	branch := &Ref{Name: name, Target: &RefTarget{Hash: target}}
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/refs/branches", owner, slug), branch, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// DeleteBranch removes the branch with the given name, bitbucket refuses to delete the main branch.
+func (s *RefsService) DeleteBranch(ctx context.Context, owner, slug, name string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", branchEndpoint(owner, slug, name), nil, nil)
+	return err
+}
+
+func tagEndpoint(owner, slug, name string) string {
+	return apiPath("2.0/repositories/%s/%s/refs/tags/%s", owner, slug, name)
+}
+
+// GetTag fetches the tag with the given name.
+func (s *RefsService) GetTag(ctx context.Context, owner, slug, name string) (*Ref, error) {
+	var tag Ref
+	_, err := s.client.doJSON(ctx, "GET", tagEndpoint(owner, slug, name), nil, &tag)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// CreateTag creates a tag with the given name on target, a commit hash or the name of a branch. The tag
+// is annotated with message unless it is empty.
+func (s *RefsService) CreateTag(ctx context.Context, owner, slug, name, target, message string) (*Ref, error) {
+	var created Ref
+	tag := &Ref{Name: name, Target: &RefTarget{Hash: target}, Message: message}
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/refs/tags", owner, slug), tag, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// DeleteTag removes the tag with the given name.
+func (s *RefsService) DeleteTag(ctx context.Context, owner, slug, name string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", tagEndpoint(owner, slug, name), nil, nil)
+	return err
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRefsGetBranchWithSlashes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.EscapedPath() != "/2.0/repositories/gob/illusions/refs/branches/release%2F1.0" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"release/1.0","target":{"hash":"5f1e0ab"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	branch, err := client.Refs.GetBranch(context.Background(), "gob", "illusions", "release/1.0")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if branch.Name != "release/1.0" || branch.Target == nil || branch.Target.Hash != "5f1e0ab" {
+		t.Fatalf("unexpected branch %+v", branch)
+	}
+}
+
+func TestRefsCreateTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/2.0/repositories/gob/illusions/refs/tags" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"name":"v1.0","target":{"hash":"master"},"message":"Release 1.0"}` {
+			t.Fatalf("unexpected body %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"v1.0","target":{"hash":"5f1e0ab"},"message":"Release 1.0\n"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	tag, err := client.Refs.CreateTag(context.Background(), "gob", "illusions", "v1.0", "master", "Release 1.0")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if tag.Target == nil || tag.Target.Hash != "5f1e0ab" {
+		t.Fatalf("unexpected tag %+v", tag)
+	}
+}
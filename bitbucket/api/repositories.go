@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+)
+
+// RepositoriesService talks to the repository endpoints.
+type RepositoriesService service
+
+// CloneURL is the internal struct we use to represent urls
+type CloneURL struct {
+	Href string `json:"href,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// PipelinesEnabled is the struct we send to turn on or turn off pipelines for a repository
+type PipelinesEnabled struct {
+	Enabled bool `json:"enabled"`
+}
+
+// BuildNumber is the number the next pipeline of a repository gets
+type BuildNumber struct {
+	Next int `json:"next"`
+}
+
+// Repository is the struct we need to send off to the Bitbucket API to create a repository. The settings
+// that can be turned off or cleared are always sent, an update leaves out what is omitted.
+type Repository struct {
+	SCM         string      `json:"scm,omitempty"`
+	HasWiki     bool        `json:"has_wiki"`
+	HasIssues   bool        `json:"has_issues"`
+	Website     string      `json:"website"`
+	IsPrivate   bool        `json:"is_private"`
+	ForkPolicy  string      `json:"fork_policy,omitempty"`
+	Language    string      `json:"language"`
+	Description string      `json:"description"`
+	Name        string      `json:"name,omitempty"`
+	Slug        string      `json:"slug,omitempty"`
+	FullName    string      `json:"full_name,omitempty"`
+	UUID        string      `json:"uuid,omitempty"`
+	MainBranch  *Ref        `json:"mainbranch,omitempty"`
+	Parent      *Repository `json:"parent,omitempty"`
+	Project     struct {
+		Key string `json:"key,omitempty"`
+	} `json:"project,omitempty"`
+	Links struct {
+		Clone []CloneURL `json:"clone,omitempty"`
+	} `json:"links,omitempty"`
+}
+
+// RepositoryFork is what we send to fork a repository into Workspace, the project and privacy of the
+// parent are kept unless set.
+type RepositoryFork struct {
+	Name        string         `json:"name"`
+	Workspace   *ForkWorkspace `json:"workspace"`
+	Project     *ForkProject   `json:"project,omitempty"`
+	Description string         `json:"description,omitempty"`
+	IsPrivate   *bool          `json:"is_private,omitempty"`
+}
+
+// ForkWorkspace is the workspace a repository is forked into.
+type ForkWorkspace struct {
+	Slug string `json:"slug"`
+}
+
+// ForkProject is the project of the workspace a repository is forked into.
+type ForkProject struct {
+	Key string `json:"key"`
+}
+
+func repositoryEndpoint(owner, slug string) string {
+	return apiPath("2.0/repositories/%s/%s", owner, slug)
+}
+
+// Get fetches a repository.
+func (s *RepositoriesService) Get(ctx context.Context, owner, slug string) (*Repository, error) {
+	var repo Repository
+	_, err := s.client.doJSON(ctx, "GET", repositoryEndpoint(owner, slug), nil, &repo)
+	if err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// Create creates the repository owner/slug.
+func (s *RepositoriesService) Create(ctx context.Context, owner, slug string, repo *Repository) (*Repository, error) {
+	var created Repository
+	_, err := s.client.doJSON(ctx, "POST", repositoryEndpoint(owner, slug), repo, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the settings of a repository.
+func (s *RepositoriesService) Update(ctx context.Context, owner, slug string, repo *Repository) (*Repository, error) {
+	var updated Repository
+	_, err := s.client.doJSON(ctx, "PUT", repositoryEndpoint(owner, slug), repo, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Fork forks the repository owner/slug. Bitbucket copies the commits in the background, the fork may
+// not have its branches yet when it is returned.
+func (s *RepositoriesService) Fork(ctx context.Context, owner, slug string, fork *RepositoryFork) (*Repository, error) {
+	var created Repository
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/repositories/%s/%s/forks", owner, slug), fork, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateMainBranch makes the branch with the given name, which has to exist, the main branch of a
+// repository. Only the main branch is sent, the other settings are left as they are.
+func (s *RepositoriesService) UpdateMainBranch(ctx context.Context, owner, slug, name string) error {
+	change := struct {
+		MainBranch *Ref `json:"mainbranch"`
+	}{&Ref{Name: name}}
+	_, err := s.client.doJSON(ctx, "PUT", repositoryEndpoint(owner, slug), change, nil)
+	return err
+}
+
+// Delete deletes a repository.
+func (s *RepositoriesService) Delete(ctx context.Context, owner, slug string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", repositoryEndpoint(owner, slug), nil, nil)
+	return err
+}
+
+// GetPipelinesConfig fetches whether pipelines are enabled for a repository.
+func (s *RepositoriesService) GetPipelinesConfig(ctx context.Context, owner, slug string) (*PipelinesEnabled, error) {
+	var config PipelinesEnabled
+	_, err := s.client.doJSON(ctx, "GET", apiPath("2.0/repositories/%s/%s/pipelines_config", owner, slug), nil, &config)
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// UpdatePipelinesConfig turns pipelines on or off for a repository.
+func (s *RepositoriesService) UpdatePipelinesConfig(ctx context.Context, owner, slug string, config *PipelinesEnabled) error {
+	_, err := s.client.doJSON(ctx, "PUT", apiPath("2.0/repositories/%s/%s/pipelines_config", owner, slug), config, nil)
+	return err
+}
+
+// GetBuildNumber fetches the number the next pipeline of a repository gets.
+func (s *RepositoriesService) GetBuildNumber(ctx context.Context, owner, slug string) (*BuildNumber, error) {
+	var number BuildNumber
+	_, err := s.client.doJSON(ctx, "GET", apiPath("2.0/repositories/%s/%s/pipelines_config/build_number", owner, slug), nil, &number)
+	if err != nil {
+		return nil, err
+	}
+	return &number, nil
+}
+
+// UpdateBuildNumber raises the number the next pipeline of a repository gets, bitbucket refuses to lower it.
+func (s *RepositoriesService) UpdateBuildNumber(ctx context.Context, owner, slug string, number *BuildNumber) error {
+	_, err := s.client.doJSON(ctx, "PUT", apiPath("2.0/repositories/%s/%s/pipelines_config/build_number", owner, slug), number, nil)
+	return err
+}
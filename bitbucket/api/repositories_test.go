@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRepositoriesFork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/2.0/repositories/gob/illusions/forks" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"name":"Illusions","workspace":{"slug":"bluth"},"project":{"key":"MAGIC"}}` {
+			t.Fatalf("unexpected body %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"Illusions","slug":"illusions","full_name":"bluth/illusions","parent":{"full_name":"gob/illusions"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	fork, err := client.Repositories.Fork(context.Background(), "gob", "illusions", &RepositoryFork{
+		Name:      "Illusions",
+		Workspace: &ForkWorkspace{Slug: "bluth"},
+		Project:   &ForkProject{Key: "MAGIC"},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if fork.Slug != "illusions" || fork.Parent == nil || fork.Parent.FullName != "gob/illusions" {
+		t.Fatalf("unexpected fork %+v", fork)
+	}
+}
+
+func TestRepositoriesUpdateMainBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/2.0/repositories/gob/illusions" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"mainbranch":{"name":"develop"}}` {
+			t.Fatalf("expected only the main branch to be sent, got %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"slug":"illusions","mainbranch":{"name":"develop","type":"branch"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	if err := client.Repositories.UpdateMainBranch(context.Background(), "gob", "illusions", "develop"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
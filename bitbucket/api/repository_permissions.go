@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+)
+
+// RepositoryPermissionsService talks to the explicit permission endpoints of a repository.
+type RepositoryPermissionsService service
+
+// RepositoryPermission is the permission, read, write or admin, a group or user has on a repository.
+type RepositoryPermission struct {
+	Permission string          `json:"permission"`
+	Group      *WorkspaceGroup `json:"group,omitempty"`
+	User       *User           `json:"user,omitempty"`
+}
+
+func repositoryGroupPermissionEndpoint(owner, slug, group string) string {
+	return apiPath("2.0/repositories/%s/%s/permissions-config/groups/%s", owner, slug, group)
+}
+
+// GetGroup fetches the permission of the group with the given slug on a repository.
+func (s *RepositoryPermissionsService) GetGroup(ctx context.Context, owner, slug, group string) (*RepositoryPermission, error) {
+	var permission RepositoryPermission
+	_, err := s.client.doJSON(ctx, "GET", repositoryGroupPermissionEndpoint(owner, slug, group), nil, &permission)
+	if err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// UpdateGroup grants the group with the given slug permission on a repository, replacing the one it had.
+func (s *RepositoryPermissionsService) UpdateGroup(ctx context.Context, owner, slug, group, permission string) error {
+	_, err := s.client.doJSON(ctx, "PUT", repositoryGroupPermissionEndpoint(owner, slug, group), &RepositoryPermission{Permission: permission}, nil)
+	return err
+}
+
+// DeleteGroup takes the explicit permission of the group with the given slug on a repository away.
+func (s *RepositoryPermissionsService) DeleteGroup(ctx context.Context, owner, slug, group string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", repositoryGroupPermissionEndpoint(owner, slug, group), nil, nil)
+	return err
+}
+
+func repositoryUserPermissionEndpoint(owner, slug, user string) string {
+	return apiPath("2.0/repositories/%s/%s/permissions-config/users/%s", owner, slug, user)
+}
+
+// GetUser fetches the permission of the user with the given uuid or account id on a repository.
+func (s *RepositoryPermissionsService) GetUser(ctx context.Context, owner, slug, user string) (*RepositoryPermission, error) {
+	var permission RepositoryPermission
+	_, err := s.client.doJSON(ctx, "GET", repositoryUserPermissionEndpoint(owner, slug, user), nil, &permission)
+	if err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// UpdateUser grants the user with the given uuid or account id permission on a repository, replacing the
+// one they had.
+func (s *RepositoryPermissionsService) UpdateUser(ctx context.Context, owner, slug, user, permission string) error {
+	_, err := s.client.doJSON(ctx, "PUT", repositoryUserPermissionEndpoint(owner, slug, user), &RepositoryPermission{Permission: permission}, nil)
+	return err
+}
+
+// DeleteUser takes the explicit permission of the user with the given uuid or account id on a repository
+// away.
+func (s *RepositoryPermissionsService) DeleteUser(ctx context.Context, owner, slug, user string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", repositoryUserPermissionEndpoint(owner, slug, user), nil, nil)
+	return err
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// RepositoryVariablesService talks to the pipelines variable endpoints of a repository.
+type RepositoryVariablesService service
+
+// RepositoryVariable structure for handling key info
+type RepositoryVariable struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	UUID    string `json:"uuid,omitempty"`
+	Secured bool   `json:"secured"`
+}
+
+func repositoryVariablesEndpoint(owner, slug string) string {
+	return apiPath("2.0/repositories/%s/%s/pipelines_config/variables/", owner, slug)
+}
+
+// List fetches every pipelines variable of a repository.
+func (s *RepositoryVariablesService) List(ctx context.Context, owner, slug string) ([]RepositoryVariable, error) {
+	return s.Search(ctx, owner, slug, Query{})
+}
+
+// Search fetches the pipelines variables of a repository matching q, bitbucket filters them.
+func (s *RepositoryVariablesService) Search(ctx context.Context, owner, slug string, q Query) ([]RepositoryVariable, error) {
+	var variables []RepositoryVariable
+	endpoint := WithQuery(repositoryVariablesEndpoint(owner, slug), q)
+
+	err := s.client.listPages(ctx, endpoint, func(values json.RawMessage) error {
+		var page []RepositoryVariable
+		if err := decodeJSON(endpoint, values, &page); err != nil {
+			return err
+		}
+		variables = append(variables, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return variables, nil
+}
+
+// Get fetches the repository variable with the given uuid.
+func (s *RepositoryVariablesService) Get(ctx context.Context, owner, slug, uuid string) (*RepositoryVariable, error) {
+	var variable RepositoryVariable
+	_, err := s.client.doJSON(ctx, "GET", apiPath("2.0/repositories/%s/%s/pipelines_config/variables/%s", owner, slug, uuid), nil, &variable)
+	if err != nil {
+		return nil, err
+	}
+	return &variable, nil
+}
+
+// Create adds a pipelines variable to a repository.
+func (s *RepositoryVariablesService) Create(ctx context.Context, owner, slug string, variable *RepositoryVariable) (*RepositoryVariable, error) {
+	var created RepositoryVariable
+	_, err := s.client.doJSON(ctx, "POST", repositoryVariablesEndpoint(owner, slug), variable, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the repository variable with the given uuid.
+func (s *RepositoryVariablesService) Update(ctx context.Context, owner, slug, uuid string, variable *RepositoryVariable) (*RepositoryVariable, error) {
+	var updated RepositoryVariable
+	_, err := s.client.doJSON(ctx, "PUT", apiPath("2.0/repositories/%s/%s/pipelines_config/variables/%s", owner, slug, uuid), variable, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the repository variable with the given uuid.
+func (s *RepositoryVariablesService) Delete(ctx context.Context, owner, slug, uuid string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", apiPath("2.0/repositories/%s/%s/pipelines_config/variables/%s", owner, slug, uuid), nil, nil)
+	return err
+}
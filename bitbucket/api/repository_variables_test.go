@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRepositoryVariablesSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != `(uuid = "{country}") OR (key = "COUNTRY")` {
+			t.Fatalf("unexpected query %q", q)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values": [{"key": "COUNTRY", "uuid": "{country}"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	variables, err := client.RepositoryVariables.Search(context.Background(), "gob", "illusions", Or(Eq("uuid", "{country}"), Eq("key", "COUNTRY")))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(variables) != 1 || variables[0].Key != "COUNTRY" {
+		t.Fatalf("unexpected variables %+v", variables)
+	}
+}
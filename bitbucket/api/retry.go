@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// send performs the request and retries it up to MaxRetries times when it failed for a transient
+// reason, waiting a little longer between every attempt. When bitbucket rate limits the request the
+// wait it asks for in Retry-After is honored instead.
+func (c *Client) send(ctx context.Context, method, absoluteendpoint string, body []byte, contentType string) (*http.Response, error) {
+	trace := ContextRequestTrace(ctx)
+
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, method, absoluteendpoint, body, contentType)
+		if err != nil {
+			return nil, err
+		}
+
+		info := RequestInfo{Method: method, URL: absoluteendpoint, Attempt: attempt + 1}
+		trace.start(info)
+		start := time.Now()
+
+		resp, err := c.roundTrip(ctx, req)
+
+		info.Duration = time.Since(start)
+		info.Err = err
+		if resp != nil {
+			info.StatusCode = resp.StatusCode
+		}
+		trace.finish(info)
+		if ctx.Err() != nil {
+			// Cancelled or out of time, another attempt would fail the same way.
+			return resp, err
+		}
+
+		if attempt >= c.MaxRetries || !shouldRetry(method, resp, err) {
+			return resp, err
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		wait := c.backoff(attempt)
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			if after, ok := retryAfter(resp); ok {
+				wait = after
+			}
+			wait = jitter(wait)
+		}
+
+		log.Printf("[DEBUG] %s %s failed (%s), retrying in %s (%d/%d)", method, absoluteendpoint, retryReason(resp, err), wait, attempt+1, c.MaxRetries)
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleep waits for d to pass, returning early with the error of ctx when it is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// shouldRetry is true for errors that are likely to go away when the request is sent again. Bitbucket
+// may have acted on a request that failed with a 500 or a dropped connection, so those are only retried
+// when sending the request twice does no harm. A POST is only retried when it never reached bitbucket.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		return isIdempotent(method) || isDialError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusInternalServerError:
+		return isIdempotent(method)
+	}
+
+	return false
+}
+
+// isIdempotent is true for methods that leave bitbucket in the same state however often they are sent
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// isDialError is true when the connection to bitbucket could not be opened, so nothing was sent
+func isDialError(err error) bool {
+	var opError *net.OpError
+	return errors.As(err, &opError) && opError.Op == "dial"
+}
+
+func retryReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
+
+// retryAfter reads how long bitbucket wants us to wait from the Retry-After header, which is either a
+// number of seconds or a date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// jitter adds up to a fifth of wait on top of it, so parallel requests that were rate limited together
+// don't all come back at the same moment.
+func jitter(wait time.Duration) time.Duration {
+	if wait <= 0 {
+		return wait
+	}
+	return wait + time.Duration(rand.Int63n(int64(wait)/5+1))
+}
+
+// backoff doubles the wait for every attempt, starting at RetryMinWait and never exceeding RetryMaxWait.
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := c.RetryMinWait
+	for i := 0; i < attempt && wait < c.RetryMaxWait; i++ {
+		wait *= 2
+	}
+
+	if wait > c.RetryMaxWait {
+		wait = c.RetryMaxWait
+	}
+
+	return wait
+}
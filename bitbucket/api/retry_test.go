@@ -0,0 +1,256 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestClientRetry_transientErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:      server.URL,
+		HTTPClient:   server.Client(),
+		MaxRetries:   3,
+		RetryMinWait: time.Millisecond,
+		RetryMaxWait: time.Millisecond,
+	}
+
+	resp, err := client.Get(context.Background(), "2.0/user")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || attempts != 3 {
+		t.Fatalf("expected a 200 after 3 attempts, got %d after %d", resp.StatusCode, attempts)
+	}
+}
+
+func TestClientRetry_givesUp(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:      server.URL,
+		HTTPClient:   server.Client(),
+		MaxRetries:   2,
+		RetryMinWait: time.Millisecond,
+		RetryMaxWait: time.Millisecond,
+	}
+
+	if _, err := client.Get(context.Background(), "2.0/user"); err == nil {
+		t.Fatalf("expected an error after running out of retries")
+	}
+
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestClientRetry_idempotent(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:      server.URL,
+		HTTPClient:   server.Client(),
+		MaxRetries:   2,
+		RetryMinWait: time.Millisecond,
+		RetryMaxWait: time.Millisecond,
+	}
+
+	if _, err := client.Get(context.Background(), "2.0/user"); err == nil {
+		t.Fatalf("expected an error after running out of retries")
+	}
+
+	if attempts != 3 {
+		t.Fatalf("expected the GET to be sent 3 times, got %d", attempts)
+	}
+
+	attempts = 0
+	if _, err := client.Post(context.Background(), "2.0/repositories/gob/illusions/hooks", nil); err == nil {
+		t.Fatalf("expected an error for the 500")
+	}
+
+	if attempts != 1 {
+		t.Fatalf("expected the POST to be sent once, got %d", attempts)
+	}
+}
+
+func TestShouldRetry_networkErrors(t *testing.T) {
+	reset := &url.Error{Op: "Post", URL: "https://api.bitbucket.org/", Err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}}
+	refused := &url.Error{Op: "Post", URL: "https://api.bitbucket.org/", Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}
+
+	if !shouldRetry("DELETE", nil, reset) {
+		t.Fatalf("expected a reset DELETE to be retried")
+	}
+
+	if shouldRetry("POST", nil, reset) {
+		t.Fatalf("expected a reset POST not to be retried")
+	}
+
+	if !shouldRetry("POST", nil, refused) {
+		t.Fatalf("expected a POST that could not connect to be retried")
+	}
+}
+
+func TestClientRetry_rateLimited(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:      server.URL,
+		HTTPClient:   server.Client(),
+		MaxRetries:   1,
+		RetryMinWait: time.Hour,
+		RetryMaxWait: time.Hour,
+	}
+
+	resp, err := client.Get(context.Background(), "2.0/user")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || attempts != 2 {
+		t.Fatalf("expected a 200 after 2 attempts, got %d after %d", resp.StatusCode, attempts)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := map[string]time.Duration{
+		"120": 2 * time.Minute,
+		time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat): 0,
+	}
+
+	for header, expected := range cases {
+		resp := &http.Response{Header: http.Header{"Retry-After": {header}}}
+		if wait, ok := retryAfter(resp); !ok || wait != expected {
+			t.Fatalf("expected %q to wait %s, got %s", header, expected, wait)
+		}
+	}
+
+	for _, header := range []string{"", "soon"} {
+		resp := &http.Response{Header: http.Header{"Retry-After": {header}}}
+		if _, ok := retryAfter(resp); ok {
+			t.Fatalf("expected %q to be ignored", header)
+		}
+	}
+
+	if wait := jitter(time.Second); wait < time.Second || wait > 1200*time.Millisecond {
+		t.Fatalf("expected up to a fifth of jitter, got %s", wait)
+	}
+}
+
+func TestClientRetry_cancelled(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:      server.URL,
+		HTTPClient:   server.Client(),
+		MaxRetries:   3,
+		RetryMinWait: time.Hour,
+		RetryMaxWait: time.Hour,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.Get(ctx, "2.0/user"); err != context.DeadlineExceeded {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+
+	if time.Since(start) > time.Second || attempts != 1 {
+		t.Fatalf("expected to give up after 1 attempt once the deadline passed, took %s for %d", time.Since(start), attempts)
+	}
+}
+
+func TestClientBackoff(t *testing.T) {
+	client := &Client{RetryMinWait: time.Second, RetryMaxWait: 5 * time.Second}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for attempt, wait := range expected {
+		if got := client.backoff(attempt); got != wait {
+			t.Fatalf("attempt %d: expected %s, got %s", attempt, wait, got)
+		}
+	}
+}
+
+func TestClientParallelRequests(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		semaphore:  make(chan struct{}, 2),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Get(context.Background(), "2.0/user")
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// scopeRule is the scope bitbucket asks for to read and to change what lives under a path segment
+type scopeRule struct {
+	segment string
+	read    string
+	write   string
+}
+
+// scopeRules are checked in order, the first segment found in the endpoint decides. More specific
+// segments come first, variables live under the pipelines and environment endpoints.
+var scopeRules = []scopeRule{
+	{"/variables", "pipeline:variable", "pipeline:variable"},
+	{"/environments", "pipeline", "pipeline:write"},
+	{"/pipelines_config", "pipeline", "repository:admin"},
+	{"/hooks", "webhook", "webhook"},
+	{"/branch-restrictions", "repository:admin", "repository:admin"},
+	{"/default-reviewers", "pullrequest", "repository:admin"},
+	{"/projects", "project", "project:admin"},
+	{"2.0/repositories/", "repository", "repository:admin"},
+	{"2.0/user", "account", "account"},
+}
+
+// requiredScope guesses the scope a request needs from its endpoint, it is empty when there is no guess.
+func requiredScope(method, endpoint string) string {
+	if i := strings.Index(endpoint, "?"); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+
+	for _, rule := range scopeRules {
+		if !strings.Contains(endpoint, rule.segment) {
+			continue
+		}
+
+		switch {
+		case method == "GET" || method == "HEAD":
+			return rule.read
+		case method == "DELETE" && rule.segment == "2.0/repositories/" && strings.Count(strings.Trim(endpoint, "/"), "/") == 3:
+			return "repository:delete"
+		default:
+			return rule.write
+		}
+	}
+
+	return ""
+}
+
+// authHint explains a 401 or 403 in terms of the auth method in use. A 401 means bitbucket didn't accept
+// the credentials at all, a 403 that they lack a scope or permission. App passwords, tokens and OAuth
+// consumers are all limited to the scopes picked when they were created, so name the one bitbucket
+// wanted or the one the endpoint most likely needs.
+func (c *Client) authHint(method, endpoint string, apiError Error) string {
+	method = strings.ToUpper(method)
+	authMethod := c.AuthMethod
+	if authMethod == "" {
+		authMethod = AuthMethodPassword
+	}
+
+	if apiError.StatusCode != http.StatusForbidden {
+		return fmt.Sprintf("bitbucket did not accept the %s, check that it is correct and has not expired or been revoked", authMethod)
+	}
+
+	var detail scopeDetail
+	if err := json.Unmarshal(apiError.APIError.Detail, &detail); err == nil && len(detail.Required) > 0 {
+		return fmt.Sprintf("the %s requires the scopes [%s] for this request but was granted [%s]",
+			authMethod,
+			strings.Join(detail.Required, ", "),
+			strings.Join(detail.Granted, ", "),
+		)
+	}
+
+	if scope := requiredScope(method, endpoint); scope != "" {
+		return fmt.Sprintf("the %s is likely missing the %s scope, or the account lacks permission for %s %s", authMethod, scope, method, endpoint)
+	}
+
+	return fmt.Sprintf("check that the %s has the scopes and permissions required for %s %s", authMethod, method, endpoint)
+}
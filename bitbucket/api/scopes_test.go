@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequiredScope(t *testing.T) {
+	cases := []struct {
+		method   string
+		endpoint string
+		scope    string
+	}{
+		{"GET", "2.0/repositories/gob/illusions/pipelines_config/variables/?pagelen=100", "pipeline:variable"},
+		{"POST", "2.0/repositories/gob/illusions/deployments_config/environments/%7Bstaging%7D/variables", "pipeline:variable"},
+		{"POST", "2.0/repositories/gob/illusions/environments/", "pipeline:write"},
+		{"GET", "2.0/repositories/gob/illusions/hooks/%7Bhook%7D", "webhook"},
+		{"PUT", "2.0/repositories/gob/illusions/default-reviewers/gob", "repository:admin"},
+		{"POST", "2.0/teams/gob/projects/", "project:admin"},
+		{"GET", "2.0/repositories/gob/illusions", "repository"},
+		{"PUT", "2.0/repositories/gob/illusions", "repository:admin"},
+		{"DELETE", "2.0/repositories/gob/illusions", "repository:delete"},
+		{"GET", "2.0/user", "account"},
+		{"GET", "2.0/snippets", ""},
+	}
+
+	for _, tc := range cases {
+		if scope := requiredScope(tc.method, tc.endpoint); scope != tc.scope {
+			t.Fatalf("expected %s %s to require %q, got %q", tc.method, tc.endpoint, tc.scope, scope)
+		}
+	}
+}
+
+func TestClientError_authHints(t *testing.T) {
+	status := http.StatusForbidden
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client(), AuthMethod: AuthMethodPassword}
+
+	_, err := client.Get(context.Background(), "2.0/repositories/gob/illusions/pipelines_config/variables/")
+	if err == nil || !strings.Contains(err.Error(), "the app password is likely missing the pipeline:variable scope") {
+		t.Fatalf("expected the 403 to name the missing scope, got %v", err)
+	}
+
+	status = http.StatusUnauthorized
+	_, err = client.Get(context.Background(), "2.0/user")
+	if err == nil || !strings.Contains(err.Error(), "bitbucket did not accept the app password") {
+		t.Fatalf("expected the 401 to name the auth method, got %v", err)
+	}
+}
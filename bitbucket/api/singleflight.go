@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"sync"
+)
+
+// flightGroup coalesces identical GETs that are in flight at the same time. Terraform refreshes many
+// resources at once and all variables of an environment list the same collection, only the first of
+// them sends the request and the others are handed its body.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+type flight struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{flights: make(map[string]*flight)}
+}
+
+// do calls fn for key unless a call for the same key is in flight already, then it waits for that one.
+// shared tells if the result came from another call.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() ([]byte, error)) (body []byte, shared bool, err error) {
+	g.mu.Lock()
+	if f, ok := g.flights[key]; ok {
+		g.mu.Unlock()
+
+		select {
+		case <-f.done:
+			return f.body, true, f.err
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		}
+	}
+
+	f := &flight{done: make(chan struct{})}
+	g.flights[key] = f
+	g.mu.Unlock()
+
+	f.body, f.err = fn()
+
+	g.mu.Lock()
+	delete(g.flights, key)
+	g.mu.Unlock()
+	close(f.done)
+
+	return f.body, false, f.err
+}
+
+// getShared GETs endpoint and returns the body, sharing the request with identical ones in flight.
+func (c *Client) getShared(ctx context.Context, endpoint string) ([]byte, error) {
+	get := func() ([]byte, error) {
+		resp, err := c.Do(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		return ioutil.ReadAll(resp.Body)
+	}
+
+	if c.flights == nil {
+		return get()
+	}
+
+	body, shared, err := c.flights.do(ctx, endpoint, get)
+	if shared && ctx.Err() == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		// The request this one waited for ran out of time, this one still has some.
+		return get()
+	}
+
+	return body, err
+}
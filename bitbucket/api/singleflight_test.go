@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientListPages_coalesced(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		fmt.Fprint(w, `{"values": [{"key": "PASSWORD", "uuid": "{password}"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			variables, err := client.DeploymentVariables.List(context.Background(), "gob", "illusions", "{staging}")
+			if err != nil {
+				t.Errorf("err: %s", err)
+				return
+			}
+
+			if len(variables) != 1 {
+				t.Errorf("expected every caller to get the variable, got %+v", variables)
+			}
+		}()
+	}
+
+	// Wait for the first request to arrive, the others pile up behind it.
+	for atomic.LoadInt32(&requests) == 0 {
+		runtime.Gosched()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if requests != 1 {
+		t.Fatalf("expected the identical lists to share one request, got %d", requests)
+	}
+}
+
+func TestFlightGroup_cancelledFollower(t *testing.T) {
+	group := newFlightGroup()
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	go group.do(context.Background(), "key", func() ([]byte, error) {
+		close(started)
+		<-release
+		return nil, nil
+	})
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, shared, err := group.do(ctx, "key", func() ([]byte, error) { return nil, nil }); !shared || err != context.Canceled {
+		t.Fatalf("expected the cancelled follower to stop waiting, got %v", err)
+	}
+	close(release)
+}
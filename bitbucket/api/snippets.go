@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// SnippetsService talks to the snippet endpoints of a workspace.
+type SnippetsService service
+
+// Snippet is a snippet of a workspace, its Files are keyed by their name. The content of the files is
+// served separately.
+type Snippet struct {
+	ID        string              `json:"id"`
+	Title     string              `json:"title"`
+	IsPrivate bool                `json:"is_private"`
+	Files     map[string]struct{} `json:"files"`
+}
+
+func snippetEndpoint(workspace, id string) string {
+	return apiPath("2.0/snippets/%s/%s", workspace, id)
+}
+
+// snippetForm is the form snippets are created and updated with, files go as `file` parts. A `file`
+// field without content deletes the file of that name.
+func snippetForm(title string, isPrivate bool, files map[string]string, deleted []string) (url.Values, []FormFile) {
+	fields := url.Values{}
+	fields.Set("title", title)
+	fields.Set("is_private", strconv.FormatBool(isPrivate))
+	for _, name := range deleted {
+		fields.Add("file", name)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]FormFile, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, FormFile{Field: "file", Filename: name, Content: []byte(files[name])})
+	}
+
+	return fields, parts
+}
+
+// decodeSnippet reads the snippet bitbucket answers a form with
+func decodeSnippet(endpoint string, resp *http.Response) (*Snippet, error) {
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var snippet Snippet
+	if err := decodeJSON(endpoint, body, &snippet); err != nil {
+		return nil, err
+	}
+	return &snippet, nil
+}
+
+// Get fetches the snippet of a workspace with the given id.
+func (s *SnippetsService) Get(ctx context.Context, workspace, id string) (*Snippet, error) {
+	var snippet Snippet
+	_, err := s.client.doJSON(ctx, "GET", snippetEndpoint(workspace, id), nil, &snippet)
+	if err != nil {
+		return nil, err
+	}
+	return &snippet, nil
+}
+
+// GetFile fetches the content of the file of a snippet with the given name.
+func (s *SnippetsService) GetFile(ctx context.Context, workspace, id, name string) (string, error) {
+	resp, err := s.client.do(ctx, "GET", apiPath("2.0/snippets/%s/%s/files/%s", workspace, id, name), nil, "application/json")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// Create adds a snippet with files, keyed by their name, to a workspace.
+func (s *SnippetsService) Create(ctx context.Context, workspace, title string, isPrivate bool, files map[string]string) (*Snippet, error) {
+	endpoint := apiPath("2.0/snippets/%s", workspace)
+	fields, parts := snippetForm(title, isPrivate, files, nil)
+
+	resp, err := s.client.PostForm(ctx, endpoint, fields, parts)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippet(endpoint, resp)
+}
+
+// Update changes the snippet with the given id, the files it is sent are replaced and the ones named in
+// deleted are removed. The others are left as they are.
+func (s *SnippetsService) Update(ctx context.Context, workspace, id, title string, isPrivate bool, files map[string]string, deleted []string) (*Snippet, error) {
+	endpoint := snippetEndpoint(workspace, id)
+	fields, parts := snippetForm(title, isPrivate, files, deleted)
+
+	resp, err := s.client.PutForm(ctx, endpoint, fields, parts)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippet(endpoint, resp)
+}
+
+// Delete removes the snippet with the given id.
+func (s *SnippetsService) Delete(ctx context.Context, workspace, id string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", snippetEndpoint(workspace, id), nil, nil)
+	return err
+}
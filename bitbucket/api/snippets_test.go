@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSnippetsUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/2.0/snippets/gob/kypj" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if r.FormValue("title") != "Tricks" || r.FormValue("is_private") != "true" || r.FormValue("file") != "old.sh" {
+			t.Fatalf("expected the fields to be sent, got %v", r.MultipartForm.Value)
+		}
+
+		files := r.MultipartForm.File["file"]
+		if len(files) != 1 || files[0].Filename != "trick.sh" {
+			t.Fatalf("expected the file to be sent, got %v", files)
+		}
+		file, _ := files[0].Open()
+		defer file.Close()
+		content, _ := ioutil.ReadAll(file)
+		if string(content) != "echo now you see me" {
+			t.Fatalf("unexpected content %q", content)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"kypj","title":"Tricks","is_private":true,"files":{"trick.sh":{"links":{}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	snippet, err := client.Snippets.Update(context.Background(), "gob", "kypj", "Tricks", true, map[string]string{"trick.sh": "echo now you see me"}, []string{"old.sh"})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, ok := snippet.Files["trick.sh"]; !ok || len(snippet.Files) != 1 {
+		t.Fatalf("unexpected snippet %+v", snippet)
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// SourceService talks to the `src` endpoints of a repository, which serve the files of a commit and
+// commit changes to a branch.
+type SourceService service
+
+// SourceCommit is a commit of Files, keyed by path, to Branch. The files at the paths in Deleted are
+// removed by the same commit. Author is like `Name <email>` and defaults to the authenticated user.
+type SourceCommit struct {
+	Branch  string
+	Message string
+	Author  string
+	Files   map[string]string
+	Deleted []string
+}
+
+// filePath escapes every segment of the path of a file but keeps the slashes between them
+func filePath(file string) string {
+	segments := strings.Split(strings.TrimPrefix(file, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// GetFile fetches the content of the file at path as of ref, a branch, tag or commit hash.
+func (s *SourceService) GetFile(ctx context.Context, owner, slug, ref, file string) (string, error) {
+	resp, err := s.client.do(ctx, "GET", apiPath("2.0/repositories/%s/%s/src/%s/", owner, slug, ref)+filePath(file), nil, "application/json")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// Commit commits the files of commit and returns the hash of the commit, which bitbucket only hands out
+// as the Location of the response.
+func (s *SourceService) Commit(ctx context.Context, owner, slug string, commit *SourceCommit) (string, error) {
+	fields := url.Values{}
+	fields.Set("branch", commit.Branch)
+	if commit.Message != "" {
+		fields.Set("message", commit.Message)
+	}
+	if commit.Author != "" {
+		fields.Set("author", commit.Author)
+	}
+	for _, deleted := range commit.Deleted {
+		fields.Add("files", deleted)
+	}
+
+	paths := make([]string, 0, len(commit.Files))
+	for file := range commit.Files {
+		paths = append(paths, file)
+	}
+	sort.Strings(paths)
+
+	files := make([]FormFile, 0, len(paths))
+	for _, file := range paths {
+		files = append(files, FormFile{Field: file, Filename: path.Base(file), Content: []byte(commit.Files[file])})
+	}
+
+	resp, err := s.client.PostForm(ctx, apiPath("2.0/repositories/%s/%s/src", owner, slug), fields, files)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	location := resp.Header.Get("Location")
+	return location[strings.LastIndex(location, "/")+1:], nil
+}
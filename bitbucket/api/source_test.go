@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSourceCommit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/2.0/repositories/gob/illusions/src" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		deleted := r.MultipartForm.Value["files"]
+		if r.FormValue("branch") != "master" || len(deleted) != 2 || deleted[0] != "old.txt" || deleted[1] != "older.txt" {
+			t.Fatalf("expected the branch and deleted files to be sent, got %v", r.MultipartForm.Value)
+		}
+
+		file, _, err := r.FormFile("tricks/README.md")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		defer file.Close()
+
+		content, _ := ioutil.ReadAll(file)
+		if string(content) != "# Tricks" {
+			t.Fatalf("expected the file to be sent, got %q", content)
+		}
+
+		w.Header().Set("Location", "https://api.bitbucket.org/2.0/repositories/gob/illusions/commit/5f1e0ab")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	hash, err := client.Source.Commit(context.Background(), "gob", "illusions", &SourceCommit{
+		Branch:  "master",
+		Files:   map[string]string{"tricks/README.md": "# Tricks"},
+		Deleted: []string{"old.txt", "older.txt"},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if hash != "5f1e0ab" {
+		t.Fatalf("expected the hash of the commit, got %q", hash)
+	}
+}
+
+func TestSourceGetFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/2.0/repositories/gob/illusions/src/release%2F1.0/tricks/the%20final%20countdown.md" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+		w.Write([]byte("# The Final Countdown"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	content, err := client.Source.GetFile(context.Background(), "gob", "illusions", "release/1.0", "tricks/the final countdown.md")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if content != "# The Final Countdown" {
+		t.Fatalf("unexpected content %q", content)
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+)
+
+// SSHKeysService talks to the ssh key endpoints of a user.
+type SSHKeysService service
+
+// SSHKey is a public key that can access everything its user can. Bitbucket splits the comment off the
+// key and keeps it as Comment.
+type SSHKey struct {
+	UUID      string `json:"uuid,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Label     string `json:"label,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedOn string `json:"created_on,omitempty"`
+	LastUsed  string `json:"last_used,omitempty"`
+}
+
+func sshKeyEndpoint(user, uuid string) string {
+	return apiPath("2.0/users/%s/ssh-keys/%s", user, uuid)
+}
+
+// Get fetches the ssh key of user with the given uuid.
+func (s *SSHKeysService) Get(ctx context.Context, user, uuid string) (*SSHKey, error) {
+	var key SSHKey
+	_, err := s.client.doJSON(ctx, "GET", sshKeyEndpoint(user, uuid), nil, &key)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Create adds an ssh key to user.
+func (s *SSHKeysService) Create(ctx context.Context, user string, key *SSHKey) (*SSHKey, error) {
+	var created SSHKey
+	_, err := s.client.doJSON(ctx, "POST", apiPath("2.0/users/%s/ssh-keys", user), key, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the label of the ssh key of user with the given uuid.
+func (s *SSHKeysService) Update(ctx context.Context, user, uuid string, key *SSHKey) (*SSHKey, error) {
+	var updated SSHKey
+	_, err := s.client.doJSON(ctx, "PUT", sshKeyEndpoint(user, uuid), key, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the ssh key of user with the given uuid.
+func (s *SSHKeysService) Delete(ctx context.Context, user, uuid string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", sshKeyEndpoint(user, uuid), nil, nil)
+	return err
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// RequestTrace is a set of hooks that are called around every attempt at a request, either may be nil.
+// Attach one to the context of the requests that should be traced with WithRequestTrace.
+type RequestTrace struct {
+	Start  func(info RequestInfo)
+	Finish func(info RequestInfo)
+}
+
+// RequestInfo describes an attempt at a request. Duration, StatusCode and Err are only set once the
+// attempt finished, Duration includes the time spent waiting for the rate limiter.
+type RequestInfo struct {
+	Method     string
+	URL        string
+	Attempt    int
+	Duration   time.Duration
+	StatusCode int
+	Err        error
+}
+
+type requestTraceKey struct{}
+
+// WithRequestTrace returns a context that calls the hooks of trace for every request sent with it.
+func WithRequestTrace(ctx context.Context, trace *RequestTrace) context.Context {
+	return context.WithValue(ctx, requestTraceKey{}, trace)
+}
+
+// ContextRequestTrace returns the RequestTrace attached to ctx, or nil if there is none.
+func ContextRequestTrace(ctx context.Context) *RequestTrace {
+	trace, _ := ctx.Value(requestTraceKey{}).(*RequestTrace)
+	return trace
+}
+
+func (t *RequestTrace) start(info RequestInfo) {
+	if t != nil && t.Start != nil {
+		t.Start(info)
+	}
+}
+
+func (t *RequestTrace) finish(info RequestInfo) {
+	if t != nil && t.Finish != nil {
+		t.Finish(info)
+	}
+}
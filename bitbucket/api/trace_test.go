@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientRequestTrace(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:      server.URL,
+		HTTPClient:   server.Client(),
+		MaxRetries:   1,
+		RetryMinWait: time.Millisecond,
+		RetryMaxWait: time.Millisecond,
+	}
+
+	var started, finished []RequestInfo
+	ctx := WithRequestTrace(context.Background(), &RequestTrace{
+		Start:  func(info RequestInfo) { started = append(started, info) },
+		Finish: func(info RequestInfo) { finished = append(finished, info) },
+	})
+
+	if _, err := client.Get(ctx, "2.0/user"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(started) != 2 || len(finished) != 2 {
+		t.Fatalf("expected both attempts to be traced, got %d started and %d finished", len(started), len(finished))
+	}
+
+	if finished[0].StatusCode != http.StatusBadGateway || finished[1].StatusCode != http.StatusOK || finished[1].Attempt != 2 {
+		t.Fatalf("expected the status of every attempt, got %+v", finished)
+	}
+
+	if finished[1].URL != server.URL+"/2.0/user" || finished[1].Method != "GET" {
+		t.Fatalf("expected the request to be described, got %+v", finished[1])
+	}
+
+	if _, err := client.Get(context.Background(), "2.0/user"); err != nil || len(finished) != 2 {
+		t.Fatalf("expected requests without a trace not to be traced")
+	}
+}
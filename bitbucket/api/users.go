@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+)
+
+// UsersService talks to the users endpoints.
+type UsersService service
+
+// User is a bitbucket account, branch restrictions only need the Username.
+type User struct {
+	Username    string `json:"username,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	UUID        string `json:"uuid,omitempty"`
+	Nickname    string `json:"nickname,omitempty"`
+}
+
+// Get fetches the user with the given username or uuid.
+func (s *UsersService) Get(ctx context.Context, username string) (*User, error) {
+	var user User
+	_, err := s.client.doJSON(ctx, "GET", apiPath("2.0/users/%s", username), nil, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Current fetches the user the client is authenticated as.
+func (s *UsersService) Current(ctx context.Context) (*User, error) {
+	var user User
+	_, err := s.client.doJSON(ctx, "GET", "2.0/user", nil, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"sync"
+)
+
+// variableIndex remembers the variables of every deployment environment as they were last listed.
+// Bitbucket has no endpoint for a single deployment variable, without it every variable of an
+// environment would list all of them again on refresh. A write to an environment forgets what is known
+// about it.
+type variableIndex struct {
+	mu           sync.Mutex
+	environments map[string]map[string]DeploymentVariable
+}
+
+func newVariableIndex() *variableIndex {
+	return &variableIndex{environments: make(map[string]map[string]DeploymentVariable)}
+}
+
+func (i *variableIndex) get(owner, slug, deployment, uuid string) (DeploymentVariable, bool) {
+	if i == nil {
+		return DeploymentVariable{}, false
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	variable, ok := i.environments[owner+"/"+slug+"/"+deployment][uuid]
+	return variable, ok
+}
+
+func (i *variableIndex) set(owner, slug, deployment string, variables []DeploymentVariable) {
+	if i == nil {
+		return
+	}
+
+	byUUID := make(map[string]DeploymentVariable, len(variables))
+	for _, variable := range variables {
+		byUUID[variable.UUID] = variable
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.environments[owner+"/"+slug+"/"+deployment] = byUUID
+}
+
+// forget drops what is known about the variables of an environment, they changed
+func (i *variableIndex) forget(owner, slug, deployment string) {
+	if i == nil {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.environments, owner+"/"+slug+"/"+deployment)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeploymentVariablesGetListsOnce(t *testing.T) {
+	listed := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			fmt.Fprint(w, `{"key": "COUNTRY", "value": "Kenya", "uuid": "{country}"}`)
+			return
+		}
+
+		listed++
+		fmt.Fprint(w, `{"values": [{"key": "COUNTRY", "uuid": "{country}"}, {"key": "PASSWORD", "uuid": "{password}"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	for _, uuid := range []string{"{country}", "{password}", "{country}"} {
+		if _, err := client.DeploymentVariables.Get(context.Background(), "gob", "illusions", "{staging}", uuid); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	if listed != 1 {
+		t.Fatalf("expected the variables to be listed once, got %d", listed)
+	}
+
+	if _, err := client.DeploymentVariables.Get(context.Background(), "gob", "illusions", "{staging}", "{missing}"); !IsNotFound(err) {
+		t.Fatalf("expected an unknown variable not to be found, got %v", err)
+	}
+
+	if listed != 2 {
+		t.Fatalf("expected an unknown variable to list the variables again, got %d", listed)
+	}
+
+	if _, err := client.DeploymentVariables.Update(context.Background(), "gob", "illusions", "{staging}", "{country}", &DeploymentVariable{Key: "COUNTRY"}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client.DeploymentVariables.Get(context.Background(), "gob", "illusions", "{staging}", "{country}")
+	if listed != 3 {
+		t.Fatalf("expected the variables to be listed again after a change, got %d", listed)
+	}
+}
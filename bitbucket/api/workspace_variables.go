@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// WorkspaceVariablesService talks to the pipelines variable endpoints of a workspace.
+type WorkspaceVariablesService service
+
+// WorkspaceVariable is a pipelines variable every repository of a workspace sees
+type WorkspaceVariable struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	UUID    string `json:"uuid,omitempty"`
+	Secured bool   `json:"secured"`
+}
+
+func workspaceVariablesEndpoint(workspace string) string {
+	return apiPath("2.0/workspaces/%s/pipelines-config/variables", workspace)
+}
+
+func workspaceVariableEndpoint(workspace, uuid string) string {
+	return apiPath("2.0/workspaces/%s/pipelines-config/variables/%s", workspace, uuid)
+}
+
+// List fetches every pipelines variable of a workspace.
+func (s *WorkspaceVariablesService) List(ctx context.Context, workspace string) ([]WorkspaceVariable, error) {
+	return s.Search(ctx, workspace, Query{})
+}
+
+// Search fetches the pipelines variables of a workspace matching q, bitbucket filters them.
+func (s *WorkspaceVariablesService) Search(ctx context.Context, workspace string, q Query) ([]WorkspaceVariable, error) {
+	var variables []WorkspaceVariable
+	endpoint := WithQuery(workspaceVariablesEndpoint(workspace), q)
+
+	err := s.client.listPages(ctx, endpoint, func(values json.RawMessage) error {
+		var page []WorkspaceVariable
+		if err := decodeJSON(endpoint, values, &page); err != nil {
+			return err
+		}
+		variables = append(variables, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return variables, nil
+}
+
+// Get fetches the workspace variable with the given uuid.
+func (s *WorkspaceVariablesService) Get(ctx context.Context, workspace, uuid string) (*WorkspaceVariable, error) {
+	var variable WorkspaceVariable
+	_, err := s.client.doJSON(ctx, "GET", workspaceVariableEndpoint(workspace, uuid), nil, &variable)
+	if err != nil {
+		return nil, err
+	}
+	return &variable, nil
+}
+
+// Create adds a pipelines variable to a workspace.
+func (s *WorkspaceVariablesService) Create(ctx context.Context, workspace string, variable *WorkspaceVariable) (*WorkspaceVariable, error) {
+	var created WorkspaceVariable
+	_, err := s.client.doJSON(ctx, "POST", workspaceVariablesEndpoint(workspace), variable, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update changes the workspace variable with the given uuid.
+func (s *WorkspaceVariablesService) Update(ctx context.Context, workspace, uuid string, variable *WorkspaceVariable) (*WorkspaceVariable, error) {
+	var updated WorkspaceVariable
+	_, err := s.client.doJSON(ctx, "PUT", workspaceVariableEndpoint(workspace, uuid), variable, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the workspace variable with the given uuid.
+func (s *WorkspaceVariablesService) Delete(ctx context.Context, workspace, uuid string) error {
+	_, err := s.client.doJSON(ctx, "DELETE", workspaceVariableEndpoint(workspace, uuid), nil, nil)
+	return err
+}
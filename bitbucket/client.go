@@ -1,116 +1,70 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"io/ioutil"
+	"context"
 	"log"
-	"net/http"
-)
-
-// Error represents a error from the bitbucket api.
-type Error struct {
-	APIError struct {
-		Message string `json:"message,omitempty"`
-	} `json:"error,omitempty"`
-	Type       string `json:"type,omitempty"`
-	StatusCode int
-	Endpoint   string
-}
+	"sync/atomic"
+	"time"
 
-func (e Error) Error() string {
-	return fmt.Sprintf("API Error: %d %s %s", e.StatusCode, e.Endpoint, e.APIError.Message)
-}
+	"github.com/hashicorp/terraform/helper/schema"
 
-const (
-	// BitbucketEndpoint is the fqdn used to talk to bitbucket
-	BitbucketEndpoint string = "https://api.bitbucket.org/"
+	"terraform-provider-bitbucket/bitbucket/api"
 )
 
-// Client is the base internal Client to talk to bitbuckets API. This should be a username and password
-// the password should be a app-password.
+// Client is what the provider hands to its resources. It is the api client with the settings that only
+// matter to the provider on top. StopContext is cancelled when terraform is interrupted and
+// ConsistencyTimeout caps how long to wait for a write to show up in reads, zero for no cap.
 type Client struct {
-	Username   string
-	Password   string
-	HTTPClient *http.Client
-}
-
-// Do Will just call the bitbucket api but also add auth to it and some extra headers
-func (c *Client) Do(method, endpoint string, payload *bytes.Buffer) (*http.Response, error) {
-
-	absoluteendpoint := BitbucketEndpoint + endpoint
-	log.Printf("[DEBUG] Sending request to %s %s", method, absoluteendpoint)
+	// requests is how many requests all operations of the run sent so far, first to be aligned for atomic
+	requests int64
 
-	var bodyreader io.Reader
-
-	if payload != nil {
-		log.Printf("[DEBUG] With payload %s", payload.String())
-		bodyreader = payload
-	}
-
-	req, err := http.NewRequest(method, absoluteendpoint, bodyreader)
-	if err != nil {
-		return nil, err
-	}
-
-	req.SetBasicAuth(c.Username, c.Password)
+	*api.Client
+	StopContext        context.Context
+	ConsistencyTimeout time.Duration
+}
 
-	if payload != nil {
-		// Can cause bad request when putting default reviews if set.
-		req.Header.Add("Content-Type", "application/json")
+// defaultTimeout is how long an operation may take when the timeouts block of the resource doesn't say
+const defaultTimeout = 20 * time.Minute
+
+// contextFor returns the context the requests of an operation on d are sent with. It is cancelled when
+// terraform stops the provider or once the timeout of the operation has passed, and traces every
+// request it is used for. Cancelling it logs how many requests the operation sent to which endpoints,
+// together with the total of the run so far, to show which resources cost the most of the rate limits.
+func (c *Client) contextFor(d *schema.ResourceData, timeout string) (context.Context, context.CancelFunc) {
+	ctx := c.StopContext
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	req.Close = true
+	calls := newAPICalls()
+	ctx = api.WithRequestTrace(ctx, requestTrace(timeout, d.Id(), calls))
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(timeout))
 
-	resp, err := c.HTTPClient.Do(req)
-	log.Printf("[DEBUG] Resp: %v Err: %v", resp, err)
-	if resp.StatusCode >= 400 || resp.StatusCode < 200 {
-		apiError := Error{
-			StatusCode: resp.StatusCode,
-			Endpoint:   endpoint,
-		}
+	return ctx, func() {
+		cancel()
 
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+		count, summary := calls.summary()
+		if count == 0 {
+			return
 		}
 
-		log.Printf("[DEBUG] Resp Body: %s", string(body))
-
-		err = json.Unmarshal(body, &apiError)
-		if err != nil {
-			apiError.APIError.Message = string(body)
-		}
-
-		return resp, error(apiError)
-
+		total := atomic.AddInt64(&c.requests, int64(count))
+		log.Printf("[DEBUG] Bitbucket API requests: operation=%s id=%q requests=%d run_total=%d [%s]", timeout, d.Id(), count, total, summary)
 	}
-	return resp, err
 }
 
-// Get is just a helper method to do but with a GET verb
-func (c *Client) Get(endpoint string) (*http.Response, error) {
-	return c.Do("GET", endpoint, nil)
-}
-
-// Post is just a helper method to do but with a POST verb
-func (c *Client) Post(endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
-	return c.Do("POST", endpoint, jsonpayload)
-}
-
-// Put is just a helper method to do but with a PUT verb
-func (c *Client) Put(endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
-	return c.Do("PUT", endpoint, jsonpayload)
-}
-
-// PutOnly is just a helper method to do but with a PUT verb and a nil body
-func (c *Client) PutOnly(endpoint string) (*http.Response, error) {
-	return c.Do("PUT", endpoint, nil)
-}
-
-// Delete is just a helper to Do but with a DELETE verb
-func (c *Client) Delete(endpoint string) (*http.Response, error) {
-	return c.Do("DELETE", endpoint, nil)
+// requestTrace logs every attempt at a request at TRACE level. The lines carry the operation and the id
+// of the resource that sent it, so a slow plan can be pinned on the resources responsible for it.
+func requestTrace(operation, id string, calls *apiCalls) *api.RequestTrace {
+	return &api.RequestTrace{
+		Start: func(info api.RequestInfo) {
+			log.Printf("[TRACE] Bitbucket API request started: operation=%s id=%q method=%s url=%s attempt=%d",
+				operation, id, info.Method, info.URL, info.Attempt)
+		},
+		Finish: func(info api.RequestInfo) {
+			calls.add(info.Method, info.URL)
+			log.Printf("[TRACE] Bitbucket API request finished: operation=%s id=%q method=%s url=%s attempt=%d status=%d duration=%s error=%v",
+				operation, id, info.Method, info.URL, info.Attempt, info.StatusCode, info.Duration, info.Err)
+		},
+	}
 }
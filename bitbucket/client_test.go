@@ -0,0 +1,59 @@
+package bitbucket
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func TestClientContextFor(t *testing.T) {
+	client := &Client{Client: api.NewClient(nil)}
+	d := schema.TestResourceDataRaw(t, resourceHook().Schema, map[string]interface{}{})
+	d.SetId("{hook}")
+
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatalf("expected the context to have a deadline")
+	}
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	api.ContextRequestTrace(ctx).Finish(api.RequestInfo{Method: "GET", URL: "https://api.bitbucket.org/2.0/user", Attempt: 1, StatusCode: 200})
+
+	if line := buf.String(); !strings.Contains(line, `operation=read id="{hook}" method=GET`) || !strings.Contains(line, "status=200") {
+		t.Fatalf("expected the request to be traced with the resource, got %q", line)
+	}
+}
+
+func TestClientContextFor_requestCounts(t *testing.T) {
+	client := &Client{Client: api.NewClient(nil)}
+	d := schema.TestResourceDataRaw(t, resourceHook().Schema, map[string]interface{}{})
+	d.SetId("{hook}")
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+		trace := api.ContextRequestTrace(ctx)
+		trace.Finish(api.RequestInfo{Method: "GET", URL: "https://api.bitbucket.org/2.0/repositories/gob/illusions/hooks?page=2"})
+		trace.Finish(api.RequestInfo{Method: "GET", URL: "https://api.bitbucket.org/2.0/repositories/gob/illusions/hooks"})
+		cancel()
+	}
+
+	expected := `Bitbucket API requests: operation=read id="{hook}" requests=2 run_total=4 [GET /2.0/repositories/gob/illusions/hooks: 2]`
+	if !strings.Contains(buf.String(), expected) {
+		t.Fatalf("expected the requests of the operation to be counted, got %q", buf.String())
+	}
+}
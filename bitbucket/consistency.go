@@ -0,0 +1,39 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// waitUntilVisible waits for a write to show up in reads. Bitbucket serves some collections from a cache
+// that takes a few seconds to catch up, reading them right after a write can miss it. visible is called
+// with a wait that doubles in between until it reports true or the timeout of the operation ctx belongs
+// to has passed. The consistency_timeout of the provider caps the wait further when it is set.
+func (c *Client) waitUntilVisible(ctx context.Context, what string, visible func() (bool, error)) error {
+	limit := "before the timeout of the operation"
+	if deadline, ok := ctx.Deadline(); c.ConsistencyTimeout > 0 && (!ok || time.Until(deadline) > c.ConsistencyTimeout) {
+		limit = fmt.Sprintf("within %s", c.ConsistencyTimeout)
+	}
+
+	var refreshErr error
+	_, err := waitForState(ctx, stateChange{
+		What:    what,
+		Pending: []string{"missing"},
+		Target:  []string{"visible"},
+		Timeout: c.ConsistencyTimeout,
+		Refresh: func() (string, error) {
+			var ok bool
+			ok, refreshErr = visible()
+			if ok {
+				return "visible", refreshErr
+			}
+			return "missing", refreshErr
+		},
+	})
+	if err != nil && refreshErr == nil && ctx.Err() == nil {
+		return fmt.Errorf("%s did not show up %s, bitbucket may still be catching up", what, limit)
+	}
+
+	return err
+}
@@ -0,0 +1,73 @@
+package bitbucket
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientWaitUntilVisible(t *testing.T) {
+	client := &Client{ConsistencyTimeout: 5 * time.Second}
+
+	checks := 0
+	err := client.waitUntilVisible(context.Background(), "variable", func() (bool, error) {
+		checks++
+		return checks == 2, nil
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if checks != 2 {
+		t.Fatalf("expected to check until the variable was visible, got %d checks", checks)
+	}
+}
+
+func TestClientWaitUntilVisible_timeout(t *testing.T) {
+	client := &Client{ConsistencyTimeout: 100 * time.Millisecond}
+
+	err := client.waitUntilVisible(context.Background(), "variable", func() (bool, error) {
+		return false, nil
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "within 100ms") {
+		t.Fatalf("expected the consistency_timeout to cap the wait, got %v", err)
+	}
+}
+
+func TestClientWaitUntilVisible_operationTimeout(t *testing.T) {
+	client := &Client{ConsistencyTimeout: time.Minute}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.waitUntilVisible(ctx, "variable", func() (bool, error) {
+		return false, nil
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "before the timeout of the operation") {
+		t.Fatalf("expected the timeout of the operation to bound the wait, got %v", err)
+	}
+
+	if time.Since(start) > time.Second {
+		t.Fatalf("expected to stop waiting once the operation timed out, waited %s", time.Since(start))
+	}
+}
+
+func TestClientWaitUntilVisible_noConsistencyTimeout(t *testing.T) {
+	client := &Client{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	checks := 0
+	err := client.waitUntilVisible(ctx, "variable", func() (bool, error) {
+		checks++
+		return checks == 2, nil
+	})
+	if err != nil {
+		t.Fatalf("expected to wait until the timeout of the operation without a consistency_timeout, got %v after %d checks", err, checks)
+	}
+}
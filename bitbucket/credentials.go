@@ -0,0 +1,248 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"golang.org/x/oauth2"
+	bitbucketoauth "golang.org/x/oauth2/bitbucket"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+// credentials is every way of authenticating against bitbucket the provider knows about
+type credentials struct {
+	Username                  string
+	Password                  string
+	Email                     string
+	APIToken                  string
+	OAuthToken                string
+	WorkspaceToken            string
+	RepositoryToken           string
+	RepositoryTokenRepository string
+	OAuthClientID             string
+	OAuthClientSecret         string
+	UsePipelineOIDC           bool
+}
+
+// The grant and token type used to swap the OIDC token of a pipelines step for an access token.
+const (
+	tokenExchangeGrantType string = "urn:ietf:params:oauth:grant-type:token-exchange"
+	idTokenType            string = "urn:ietf:params:oauth:token-type:id_token"
+)
+
+// authArguments are the provider arguments of every way of authenticating, in the order of precedence.
+// Arguments of different groups conflict with each other.
+var authArguments = [][]string{
+	{"oauth_token"},
+	{"workspace_token"},
+	{"repository_token", "repository_token_repository"},
+	{"oauth_client_id", "oauth_client_secret"},
+	{"email", "api_token"},
+	{"username", "password"},
+}
+
+// conflictingAuthArguments lists the auth arguments that can't be set together with key
+func conflictingAuthArguments(key string) []string {
+	var conflicts []string
+	for _, group := range authArguments {
+		inGroup := false
+		for _, argument := range group {
+			if argument == key {
+				inGroup = true
+			}
+		}
+
+		if !inGroup {
+			conflicts = append(conflicts, group...)
+		}
+	}
+	return conflicts
+}
+
+// credentialsFromConfig reads the credentials set in the provider block, each one not set there falls
+// back to its BITBUCKET_* environment variable
+func credentialsFromConfig(d *schema.ResourceData) credentials {
+	return credentials{
+		Username:                  d.Get("username").(string),
+		Password:                  d.Get("password").(string),
+		Email:                     d.Get("email").(string),
+		APIToken:                  d.Get("api_token").(string),
+		OAuthToken:                d.Get("oauth_token").(string),
+		WorkspaceToken:            d.Get("workspace_token").(string),
+		RepositoryToken:           d.Get("repository_token").(string),
+		RepositoryTokenRepository: d.Get("repository_token_repository").(string),
+		OAuthClientID:             d.Get("oauth_client_id").(string),
+		OAuthClientSecret:         d.Get("oauth_client_secret").(string),
+		UsePipelineOIDC:           d.Get("use_pipeline_oidc").(bool),
+	}
+}
+
+// methods lists the ways of authenticating that have at least one credential set, in the order of
+// precedence: use_pipeline_oidc, oauth_token, workspace_token, repository_token,
+// oauth_client_id/oauth_client_secret, email/api_token and finally username/password. The OAuth consumer
+// is part of use_pipeline_oidc when that is set, it authenticates the token exchange.
+func (c credentials) methods() []string {
+	var methods []string
+
+	if c.UsePipelineOIDC {
+		methods = append(methods, "use_pipeline_oidc")
+	}
+	if c.OAuthToken != "" {
+		methods = append(methods, "oauth_token")
+	}
+	if c.WorkspaceToken != "" {
+		methods = append(methods, "workspace_token")
+	}
+	if c.RepositoryToken != "" || c.RepositoryTokenRepository != "" {
+		methods = append(methods, "repository_token")
+	}
+	if !c.UsePipelineOIDC && (c.OAuthClientID != "" || c.OAuthClientSecret != "") {
+		methods = append(methods, "oauth_client_id/oauth_client_secret")
+	}
+	if c.Email != "" || c.APIToken != "" {
+		methods = append(methods, "email/api_token")
+	}
+	if c.Username != "" || c.Password != "" {
+		methods = append(methods, "username/password")
+	}
+
+	return methods
+}
+
+// configure sets up the client auth. Only one way of authenticating may be configured, authenticating
+// as someone else than intended because of a forgotten credential is worse than failing.
+func (c credentials) configure(client *api.Client) error {
+	if methods := c.methods(); len(methods) > 1 {
+		return fmt.Errorf("Conflicting credentials are set: %s. Only one way of authenticating can be used, "+
+			"as it is %s would be used. Remove the other credentials from the provider block or the environment",
+			strings.Join(methods, ", "),
+			methods[0],
+		)
+	}
+
+	if c.UsePipelineOIDC {
+		idToken := os.Getenv("BITBUCKET_STEP_OIDC_TOKEN")
+		if idToken == "" {
+			return fmt.Errorf("use_pipeline_oidc is set but BITBUCKET_STEP_OIDC_TOKEN is empty, set `oidc: true` on the pipelines step running terraform")
+		}
+
+		client.TokenSource = c.pipelineOIDCTokenSource(client, idToken)
+		client.AuthMethod = api.AuthMethodPipelineOIDC
+		return nil
+	}
+
+	if c.OAuthToken != "" {
+		client.TokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.OAuthToken})
+		client.AuthMethod = api.AuthMethodOAuthToken
+		return nil
+	}
+
+	if c.WorkspaceToken != "" {
+		client.TokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.WorkspaceToken})
+		client.AuthMethod = api.AuthMethodWorkspaceToken
+		return nil
+	}
+
+	if c.RepositoryToken != "" {
+		if len(strings.Split(c.RepositoryTokenRepository, "/")) != 2 {
+			return fmt.Errorf("repository_token_repository must be set to the `owner/slug` of the repository the repository_token belongs to")
+		}
+
+		client.TokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.RepositoryToken})
+		client.TokenRepository = c.RepositoryTokenRepository
+		client.AuthMethod = api.AuthMethodRepositoryToken
+		return nil
+	}
+
+	if c.OAuthClientID != "" || c.OAuthClientSecret != "" {
+		if c.OAuthClientID == "" || c.OAuthClientSecret == "" {
+			return fmt.Errorf("oauth_client_id and oauth_client_secret must be set together")
+		}
+
+		config := &clientcredentials.Config{
+			ClientID:     c.OAuthClientID,
+			ClientSecret: c.OAuthClientSecret,
+			TokenURL:     bitbucketoauth.Endpoint.TokenURL,
+			AuthStyle:    oauth2.AuthStyleInHeader,
+		}
+
+		// The token requests should go through the same http client as the api requests.
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client.HTTPClient)
+		client.TokenSource = config.TokenSource(ctx)
+		client.AuthMethod = api.AuthMethodOAuthClient
+		return nil
+	}
+
+	if c.Email != "" || c.APIToken != "" {
+		if c.Email == "" || c.APIToken == "" {
+			return fmt.Errorf("email and api_token must be set together")
+		}
+
+		client.Email = c.Email
+		client.APIToken = c.APIToken
+		client.AuthMethod = api.AuthMethodAPIToken
+		return nil
+	}
+
+	if c.Username == "" || c.Password == "" {
+		return fmt.Errorf("either username and password, email and api_token, oauth_token, workspace_token, repository_token, oauth_client_id and oauth_client_secret or use_pipeline_oidc must be set")
+	}
+
+	client.Username = c.Username
+	client.Password = c.Password
+	client.AuthMethod = api.AuthMethodPassword
+	return nil
+}
+
+// pipelineOIDCTokenSource exchanges the OIDC ID token bitbucket hands to a pipelines step for an access
+// token. If an OAuth consumer is configured it is used to authenticate the exchange.
+func (c credentials) pipelineOIDCTokenSource(client *api.Client, idToken string) oauth2.TokenSource {
+	config := &clientcredentials.Config{
+		ClientID:     c.OAuthClientID,
+		ClientSecret: c.OAuthClientSecret,
+		TokenURL:     bitbucketoauth.Endpoint.TokenURL,
+		AuthStyle:    oauth2.AuthStyleInParams,
+		EndpointParams: url.Values{
+			"grant_type":         {tokenExchangeGrantType},
+			"subject_token":      {idToken},
+			"subject_token_type": {idTokenType},
+		},
+	}
+
+	if c.OAuthClientID != "" {
+		config.AuthStyle = oauth2.AuthStyleInHeader
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client.HTTPClient)
+	return config.TokenSource(ctx)
+}
+
+// validateCredentials sends a cheap request with the configured credentials so a typo in a password or
+// an expired token is reported once when the provider is configured instead of by every resource.
+// Access tokens don't belong to a user, so they are checked against what they were issued for.
+func (c *Client) validateCredentials(ctx context.Context) error {
+	endpoint, ok := c.CredentialsEndpoint()
+	if !ok {
+		log.Printf("[DEBUG] Not validating the %s, there is nothing to check it against", c.AuthMethod)
+		return nil
+	}
+
+	resp, err := c.Get(ctx, endpoint)
+	if err != nil {
+		if apiError, ok := err.(api.Error); ok && apiError.StatusCode == http.StatusUnauthorized {
+			return fmt.Errorf("Bitbucket rejected the %s, check that it is correct and has not expired", c.AuthMethod)
+		}
+		return fmt.Errorf("Could not validate the %s: %s", c.AuthMethod, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
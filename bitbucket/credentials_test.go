@@ -0,0 +1,154 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func TestCredentialsConfigure(t *testing.T) {
+	cases := []struct {
+		creds  credentials
+		method string
+	}{
+		{credentials{Username: "gob", Password: "illusions"}, api.AuthMethodPassword},
+		{credentials{Email: "gob@bluth.com", APIToken: "token"}, api.AuthMethodAPIToken},
+		{credentials{OAuthClientID: "key", OAuthClientSecret: "secret"}, api.AuthMethodOAuthClient},
+		{credentials{RepositoryToken: "token", RepositoryTokenRepository: "gob/illusions"}, api.AuthMethodRepositoryToken},
+		{credentials{WorkspaceToken: "token"}, api.AuthMethodWorkspaceToken},
+		{credentials{OAuthToken: "token"}, api.AuthMethodOAuthToken},
+	}
+
+	for _, tc := range cases {
+		client := &api.Client{}
+		if err := tc.creds.configure(client); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if client.AuthMethod != tc.method {
+			t.Fatalf("expected %q to be used, got %q", tc.method, client.AuthMethod)
+		}
+	}
+}
+
+func TestCredentialsConfigure_conflicts(t *testing.T) {
+	cases := []struct {
+		creds credentials
+		used  string
+	}{
+		{credentials{Username: "gob", Password: "illusions", Email: "gob@bluth.com", APIToken: "token"}, "email/api_token"},
+		{credentials{Email: "gob@bluth.com", OAuthClientID: "key", OAuthClientSecret: "secret"}, "oauth_client_id/oauth_client_secret"},
+		{credentials{OAuthClientID: "key", RepositoryToken: "token", RepositoryTokenRepository: "gob/illusions"}, "repository_token"},
+		{credentials{RepositoryToken: "token", WorkspaceToken: "token"}, "workspace_token"},
+		{credentials{WorkspaceToken: "token", OAuthToken: "token", Password: "illusions"}, "oauth_token"},
+	}
+
+	for _, tc := range cases {
+		err := tc.creds.configure(&api.Client{})
+		if err == nil {
+			t.Fatalf("expected an error for %+v", tc.creds)
+		}
+
+		if !strings.Contains(err.Error(), fmt.Sprintf("%s would be used", tc.used)) {
+			t.Fatalf("expected the error to say %s would be used, got %s", tc.used, err)
+		}
+	}
+}
+
+func TestCredentialsConfigure_incomplete(t *testing.T) {
+	cases := []credentials{
+		{},
+		{Username: "gob"},
+		{Email: "gob@bluth.com"},
+		{OAuthClientSecret: "secret"},
+		{RepositoryToken: "token"},
+	}
+
+	for _, creds := range cases {
+		if err := creds.configure(&api.Client{}); err == nil {
+			t.Fatalf("expected an error for %+v", creds)
+		}
+	}
+}
+
+func TestCredentialsFromConfig_env(t *testing.T) {
+	defer os.Unsetenv("BITBUCKET_APP_PASSWORD")
+	defer os.Setenv("BITBUCKET_PASSWORD", os.Getenv("BITBUCKET_PASSWORD"))
+	defer os.Setenv("BITBUCKET_USERNAME", os.Getenv("BITBUCKET_USERNAME"))
+
+	os.Unsetenv("BITBUCKET_PASSWORD")
+	os.Setenv("BITBUCKET_APP_PASSWORD", "illusions")
+	os.Setenv("BITBUCKET_USERNAME", "buster")
+
+	// The username of the provider block wins, the password falls back to the environment
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, map[string]interface{}{
+		"username": "gob",
+	})
+
+	creds := credentialsFromConfig(d)
+	if creds.Username != "gob" {
+		t.Fatalf("expected the username of the provider block to be used, got %q", creds.Username)
+	}
+	if creds.Password != "illusions" {
+		t.Fatalf("expected BITBUCKET_APP_PASSWORD to be used as the password, got %q", creds.Password)
+	}
+}
+
+func TestCredentialsConfigure_pipelineOIDC(t *testing.T) {
+	defer os.Setenv("BITBUCKET_STEP_OIDC_TOKEN", os.Getenv("BITBUCKET_STEP_OIDC_TOKEN"))
+
+	creds := credentials{UsePipelineOIDC: true, OAuthClientID: "key", OAuthClientSecret: "secret"}
+
+	os.Unsetenv("BITBUCKET_STEP_OIDC_TOKEN")
+	if err := creds.configure(&api.Client{}); err == nil {
+		t.Fatalf("expected an error when BITBUCKET_STEP_OIDC_TOKEN is not set")
+	}
+
+	os.Setenv("BITBUCKET_STEP_OIDC_TOKEN", "idtoken")
+	client := &api.Client{}
+	if err := creds.configure(client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if client.AuthMethod != api.AuthMethodPipelineOIDC {
+		t.Fatalf("expected %q to be used, got %q", api.AuthMethodPipelineOIDC, client.AuthMethod)
+	}
+}
+
+func TestClientValidateCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, password, _ := r.BasicAuth(); password != "illusions" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.URL.Path != "/2.0/user" {
+			t.Fatalf("expected the user to be requested, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &Client{Client: api.NewClient(server.Client())}
+	client.BaseURL = server.URL
+	if err := (credentials{Username: "gob", Password: "illusions"}).configure(client.Client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := client.validateCredentials(context.Background()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client.Password = "magic"
+	if err := client.validateCredentials(context.Background()); err == nil {
+		t.Fatalf("expected an error for a wrong password")
+	}
+}
@@ -1,18 +1,12 @@
 package bitbucket
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
 
 	"github.com/hashicorp/terraform/helper/schema"
-)
 
-type apiUser struct {
-	DisplayName string `json:"display_name"`
-	UUID        string `json:"uuid"`
-	Nickname    string `json:"nickname"`
-}
+	"terraform-provider-bitbucket/bitbucket/api"
+)
 
 func dataUser() *schema.Resource {
 	return &schema.Resource{
@@ -41,28 +35,19 @@ func dataUser() *schema.Resource {
 
 func dataReadUser(d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
+	ctx, cancel := c.contextFor(d, schema.TimeoutRead)
+	defer cancel()
 
-	username := d.Get("username")
+	username := d.Get("username").(string)
 	if username == "" {
 		return fmt.Errorf("username must not be blank")
 	}
 
-	r, err := c.Get(fmt.Sprintf("2.0/users/%s", username))
-	if err != nil {
-		return err
-	}
-
-	if r.StatusCode == http.StatusNotFound {
+	u, err := c.Users.Get(ctx, username)
+	if api.IsNotFound(err) {
 		return fmt.Errorf("user not found")
 	}
 
-	if r.StatusCode >= http.StatusInternalServerError {
-		return fmt.Errorf("internal server error fetching user")
-	}
-
-	var u apiUser
-
-	err = json.NewDecoder(r.Body).Decode(&u)
 	if err != nil {
 		return err
 	}
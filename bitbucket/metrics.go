@@ -0,0 +1,58 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// apiCalls counts the requests an operation sent by verb and endpoint. Every attempt counts, retries
+// count against the rate limits of bitbucket just like the first try.
+type apiCalls struct {
+	mu     sync.Mutex
+	counts map[string]int
+	total  int
+}
+
+func newAPICalls() *apiCalls {
+	return &apiCalls{counts: make(map[string]int)}
+}
+
+func (a *apiCalls) add(method, rawURL string) {
+	endpoint := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		endpoint = u.EscapedPath()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.counts[method+" "+endpoint]++
+	a.total++
+}
+
+// summary lists the count of every endpoint, the most requested first
+func (a *apiCalls) summary() (int, string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	keys := make([]string, 0, len(a.counts))
+	for key := range a.counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if a.counts[keys[i]] != a.counts[keys[j]] {
+			return a.counts[keys[i]] > a.counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s: %d", key, a.counts[key]))
+	}
+
+	return a.total, strings.Join(parts, ", ")
+}
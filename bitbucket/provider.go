@@ -1,51 +1,321 @@
 package bitbucket
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
+
+	"terraform-provider-bitbucket/bitbucket/api"
 )
 
 // Provider will create the necessary terraform provider to talk to the Bitbucket APIs you should
-// specify a USERNAME and PASSWORD
+// specify a USERNAME and PASSWORD, an EMAIL and API TOKEN, an OAuth, workspace or repository access token or an OAuth consumer key and secret
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"username": {
-				Required:    true,
-				Type:        schema.TypeString,
-				DefaultFunc: schema.EnvDefaultFunc("BITBUCKET_USERNAME", nil),
+				Optional:      true,
+				Type:          schema.TypeString,
+				ConflictsWith: conflictingAuthArguments("username"),
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_USERNAME", nil),
 			},
 			"password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: conflictingAuthArguments("password"),
+				DefaultFunc:   schema.MultiEnvDefaultFunc([]string{"BITBUCKET_PASSWORD", "BITBUCKET_APP_PASSWORD"}, nil),
+			},
+			"oauth_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: conflictingAuthArguments("oauth_token"),
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_OAUTH_TOKEN", nil),
+			},
+			"workspace_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: conflictingAuthArguments("workspace_token"),
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_WORKSPACE_TOKEN", nil),
+			},
+			"repository_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: conflictingAuthArguments("repository_token"),
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_REPOSITORY_TOKEN", nil),
+			},
+			"repository_token_repository": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: conflictingAuthArguments("repository_token_repository"),
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_REPOSITORY_TOKEN_REPOSITORY", nil),
+			},
+			"email": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: conflictingAuthArguments("email"),
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_EMAIL", nil),
+			},
+			"api_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: conflictingAuthArguments("api_token"),
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_API_TOKEN", nil),
+			},
+			"oauth_client_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: conflictingAuthArguments("oauth_client_id"),
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_OAUTH_CLIENT_ID", nil),
+			},
+			"oauth_client_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: conflictingAuthArguments("oauth_client_secret"),
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_OAUTH_CLIENT_SECRET", nil),
+			},
+			"use_pipeline_oidc": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("BITBUCKET_USE_PIPELINE_OIDC", false),
+			},
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("BITBUCKET_WORKSPACE", nil),
+			},
+			"base_url": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("BITBUCKET_PASSWORD", nil),
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("BITBUCKET_BASE_URL", "https://api.bitbucket.org"),
+			},
+			"proxy_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ca_cert_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"client_cert": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"client_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"insecure_skip_verify": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"request_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "60s",
+				ValidateFunc: validateDuration,
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "1s",
+				ValidateFunc: validateDuration,
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "30s",
+				ValidateFunc: validateDuration,
+			},
+			"circuit_breaker_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"consistency_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatBetween(0, math.MaxFloat64),
+			},
+			"parallel_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"pagelen": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"skip_credentials_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"user_agent_suffix": {
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 		},
-		ConfigureFunc: providerConfigure,
 		ResourcesMap: map[string]*schema.Resource{
-			"bitbucket_hook":                resourceHook(),
-			"bitbucket_default_reviewers":   resourceDefaultReviewers(),
-			"bitbucket_repository":          resourceRepository(),
-			"bitbucket_repository_variable": resourceRepositoryVariable(),
-			"bitbucket_project":             resourceProject(),
-			"bitbucket_branch_restriction":  resourceBranchRestriction(),
-			"bitbucket_deployment":          resourceDeployment(),
-			"bitbucket_deployment_variable": resourceDeploymentVariable(),
+			"bitbucket_hook":                        resourceHook(),
+			"bitbucket_default_reviewers":           resourceDefaultReviewers(),
+			"bitbucket_repository":                  resourceRepository(),
+			"bitbucket_repository_fork":             resourceRepositoryFork(),
+			"bitbucket_issue":                       resourceIssue(),
+			"bitbucket_issue_component":             resourceIssueTrackerField("components"),
+			"bitbucket_issue_milestone":             resourceIssueTrackerField("milestones"),
+			"bitbucket_issue_version":               resourceIssueTrackerField("versions"),
+			"bitbucket_repository_variable":         resourceRepositoryVariable(),
+			"bitbucket_repository_group_permission": resourceRepositoryGroupPermission(),
+			"bitbucket_repository_user_permission":  resourceRepositoryUserPermission(),
+			"bitbucket_project":                     resourceProject(),
+			"bitbucket_branch":                      resourceBranch(),
+			"bitbucket_tag":                         resourceTag(),
+			"bitbucket_default_branch":              resourceDefaultBranch(),
+			"bitbucket_commit_file":                 resourceCommitFile(),
+			"bitbucket_pull_request":                resourcePullRequest(),
+			"bitbucket_pull_request_settings":       resourcePullRequestSettings(),
+			"bitbucket_branch_restriction":          resourceBranchRestriction(),
+			"bitbucket_branching_model":             resourceBranchingModel(),
+			"bitbucket_project_branching_model":     resourceProjectBranchingModel(),
+			"bitbucket_project_default_reviewers":   resourceProjectDefaultReviewers(),
+			"bitbucket_project_group_permission":    resourceProjectGroupPermission(),
+			"bitbucket_project_user_permission":     resourceProjectUserPermission(),
+			"bitbucket_group":                       resourceGroup(),
+			"bitbucket_group_membership":            resourceGroupMembership(),
+			"bitbucket_group_default_privilege":     resourceGroupDefaultPrivilege(),
+			"bitbucket_deployment":                  resourceDeployment(),
+			"bitbucket_deployment_variable":         resourceDeploymentVariable(),
+			"bitbucket_deployment_variables":        resourceDeploymentVariables(),
+			"bitbucket_deployment_lock":             resourceDeploymentLock(),
+			"bitbucket_deploy_key":                  resourceDeployKey(),
+			"bitbucket_ssh_key":                     resourceSSHKey(),
+			"bitbucket_pipeline_ssh_key":            resourcePipelineSSHKey(),
+			"bitbucket_pipeline_known_host":         resourcePipelineKnownHost(),
+			"bitbucket_pipeline_build_number":       resourcePipelineBuildNumber(),
+			"bitbucket_pipeline_schedule":           resourcePipelineSchedule(),
+			"bitbucket_pipeline_run":                resourcePipelineRun(),
+			"bitbucket_pipeline_cache_purge":        resourcePipelineCachePurge(),
+			"bitbucket_pipelines_config":            resourcePipelinesConfig(),
+			"bitbucket_workspace_variable":          resourceWorkspaceVariable(),
+			"bitbucket_workspace_hook":              resourceWorkspaceHook(),
+			"bitbucket_snippet":                     resourceSnippet(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"bitbucket_user": dataUser(),
 		},
 	}
+
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return providerConfigure(d, provider.TerraformVersion, provider.StopContext())
+	}
+
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData, terraformVersion string, stopCtx context.Context) (interface{}, error) {
+	baseURL, err := url.Parse(d.Get("base_url").(string))
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("base_url must be an absolute url like https://api.bitbucket.org")
+	}
+
+	transport, err := newTransport(d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validated by the schema already
+	timeout, _ := time.ParseDuration(d.Get("request_timeout").(string))
+	retryMinWait, _ := time.ParseDuration(d.Get("retry_min_wait").(string))
+	retryMaxWait, _ := time.ParseDuration(d.Get("retry_max_wait").(string))
+	consistencyTimeout, _ := time.ParseDuration(d.Get("consistency_timeout").(string))
+
+	if retryMinWait > retryMaxWait {
+		return nil, fmt.Errorf("retry_min_wait must not be longer than retry_max_wait")
+	}
+
 	client := &Client{
-		Username:   d.Get("username").(string),
-		Password:   d.Get("password").(string),
-		HTTPClient: &http.Client{},
+		Client: api.NewClient(&http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		}),
+		StopContext:        stopCtx,
+		ConsistencyTimeout: consistencyTimeout,
+	}
+
+	client.BaseURL = baseURL.String()
+	client.Workspace = d.Get("workspace").(string)
+	client.UserAgent = userAgent(terraformVersion, d.Get("user_agent_suffix").(string))
+	client.MaxRetries = d.Get("max_retries").(int)
+	client.RetryMinWait = retryMinWait
+	client.RetryMaxWait = retryMaxWait
+	client.PageLen = d.Get("pagelen").(int)
+	client.SetRateLimit(d.Get("requests_per_second").(float64))
+	client.SetParallelRequests(d.Get("parallel_requests").(int))
+	client.SetCircuitBreaker(d.Get("circuit_breaker_threshold").(int))
+
+	creds := credentialsFromConfig(d)
+	if err := creds.configure(client.Client); err != nil {
+		return nil, err
+	}
+
+	if !d.Get("skip_credentials_validation").(bool) {
+		if err := client.validateCredentials(stopCtx); err != nil {
+			return nil, err
+		}
 	}
 
 	return client, nil
 }
+
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration like 30s or 5m: %s", k, err))
+	}
+	return
+}
+
+// userAgent identifies the provider and the terraform running it, like
+// `terraform-provider-bitbucket/v1.3.1 (terraform 0.13.3) my-team`.
+func userAgent(terraformVersion, suffix string) string {
+	if terraformVersion == "" {
+		// Terraform 0.12 introduced this field to the protocol, older versions don't send it.
+		terraformVersion = "0.11+compatible"
+	}
+
+	ua := fmt.Sprintf("terraform-provider-bitbucket/%s (terraform %s)", ProviderVersion, terraformVersion)
+	if suffix != "" {
+		ua = fmt.Sprintf("%s %s", ua, suffix)
+	}
+
+	return ua
+}
@@ -1,6 +1,7 @@
 package bitbucket
 
 import (
+	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"os"
@@ -29,6 +30,14 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestProvider_timeouts(t *testing.T) {
+	for name, resource := range Provider().(*schema.Provider).ResourcesMap {
+		if resource.Timeouts == nil || resource.Timeouts.Create == nil || resource.Timeouts.Delete == nil {
+			t.Fatalf("expected %s to support a timeouts block", name)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("BITBUCKET_USERNAME"); v == "" {
 		t.Fatal("BITBUCKET_USERNAME must be set for acceptence tests")
@@ -42,3 +51,11 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("BITBUCKET_TEAM must be set for acceptence tests")
 	}
 }
+
+func TestUserAgent(t *testing.T) {
+	ua := userAgent("0.13.3", "platform-team")
+	expected := fmt.Sprintf("terraform-provider-bitbucket/%s (terraform 0.13.3) platform-team", ProviderVersion)
+	if ua != expected {
+		t.Fatalf("expected %q, got %q", expected, ua)
+	}
+}
@@ -0,0 +1,131 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceBranch() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBranchCreate,
+		Read:   resourceBranchRead,
+		Delete: resourceBranchDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"target": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// splitRefId splits an id like `owner/repository/name`, the name of a branch or tag may contain slashes
+func splitRefId(id string) (string, string, string, error) {
+	idparts := strings.SplitN(id, "/", 3)
+	if len(idparts) != 3 || idparts[0] == "" || idparts[1] == "" || idparts[2] == "" {
+		return "", "", "", fmt.Errorf("Incorrect ID format, should match `owner/repository/name`")
+	}
+	return idparts[0], idparts[1], idparts[2], nil
+}
+
+func resourceBranchCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+	repository := d.Get("repository").(string)
+
+	branch, err := client.Refs.CreateBranch(ctx, owner, repository, d.Get("name").(string), d.Get("target").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", owner, repository, branch.Name))
+
+	return resourceBranchRead(d, m)
+}
+
+func resourceBranchRead(d *schema.ResourceData, m interface{}) error {
+	owner, repository, name, err := splitRefId(d.Id())
+	if err != nil {
+		return err
+	}
+	d.Set("owner", owner)
+	d.Set("repository", repository)
+	d.Set("name", name)
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	branch, err := client.Refs.GetBranch(ctx, owner, repository, name)
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// The branch moves on with every commit, the target it was started at is kept. An imported branch
+	// takes the commit it is at as its target.
+	if branch.Target != nil {
+		d.Set("hash", branch.Target.Hash)
+		if d.Get("target").(string) == "" {
+			d.Set("target", branch.Target.Hash)
+		}
+	}
+
+	return nil
+}
+
+func resourceBranchDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.Refs.DeleteBranch(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Get("name").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
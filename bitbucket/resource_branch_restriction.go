@@ -1,36 +1,14 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
-	"io/ioutil"
-	"log"
-	"net/url"
-)
 
-// BranchRestriction is the data we need to send to create a new branch restriction for the repository
-type BranchRestriction struct {
-	ID      int     `json:"id,omitempty"`
-	Kind    string  `json:"kind,omitempty"`
-	Pattern string  `json:"pattern,omitempty"`
-	Value   int     `json:"value,omitempty"`
-	Users   []User  `json:"users,omitempty"`
-	Groups  []Group `json:"groups,omitempty"`
-}
-
-// User is just the user struct we want to use for BranchRestrictions
-type User struct {
-	Username string `json:"username,omitempty"`
-}
-
-// Group is the group we want to add to a branch restriction
-type Group struct {
-	Slug  string `json:"slug,omitempty"`
-	Owner User   `json:"owner,omitempty"`
-}
+	"terraform-provider-bitbucket/bitbucket/api"
+)
 
 func resourceBranchRestriction() *schema.Resource {
 	return &schema.Resource{
@@ -39,11 +17,22 @@ func resourceBranchRestriction() *schema.Resource {
 		Update: resourceBranchRestrictionsUpdate,
 		Delete: resourceBranchRestrictionsDelete,
 		Exists: resourceBranchRestrictionsExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBranchRestrictionsImport,
+		},
+		CustomizeDiff: resourceBranchRestrictionsCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"owner": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"repository": {
@@ -64,13 +53,42 @@ func resourceBranchRestriction() *schema.Resource {
 					"enforce_merge_checks",
 					"restrict_merges",
 					"reset_pullrequest_approvals_on_change",
+					"smart_reset_pullrequest_approvals",
+					"reset_pullrequest_changes_requested_on_change",
+					"require_default_reviewer_approvals_to_merge",
+					"require_no_changes_requested",
+					"require_commits_behind",
+					"allow_auto_merge_when_builds_pass",
 					"delete",
 				},
 					false),
 			},
+			"branch_match_kind": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "glob",
+				ValidateFunc: validation.StringInSlice([]string{
+					"glob",
+					"branching_model",
+				},
+					false),
+			},
+			"branch_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"feature",
+					"bugfix",
+					"release",
+					"hotfix",
+					"development",
+					"production",
+				},
+					false),
+			},
 			"pattern": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 			},
 			"users": {
 				Type:     schema.TypeSet,
@@ -103,59 +121,71 @@ func resourceBranchRestriction() *schema.Resource {
 	}
 }
 
-func createBranchRestriction(d *schema.ResourceData) *BranchRestriction {
+func createBranchRestriction(d *schema.ResourceData) *api.BranchRestriction {
 
-	users := make([]User, 0, len(d.Get("users").(*schema.Set).List()))
+	users := make([]api.User, 0, len(d.Get("users").(*schema.Set).List()))
 
 	for _, item := range d.Get("users").(*schema.Set).List() {
-		users = append(users, User{Username: item.(string)})
+		users = append(users, api.User{Username: item.(string)})
 	}
 
-	groups := make([]Group, 0, len(d.Get("groups").(*schema.Set).List()))
+	groups := make([]api.Group, 0, len(d.Get("groups").(*schema.Set).List()))
 
 	for _, item := range d.Get("groups").(*schema.Set).List() {
 		m := item.(map[string]interface{})
-		groups = append(groups, Group{Owner: User{Username: m["owner"].(string)}, Slug: m["slug"].(string)})
+		groups = append(groups, api.Group{Owner: api.User{Username: m["owner"].(string)}, Slug: m["slug"].(string)})
 	}
 
-	return &BranchRestriction{
-		Kind:    d.Get("kind").(string),
-		Pattern: d.Get("pattern").(string),
-		Value:   d.Get("value").(int),
-		Users:   users,
-		Groups:  groups,
+	return &api.BranchRestriction{
+		Kind:            d.Get("kind").(string),
+		BranchMatchKind: d.Get("branch_match_kind").(string),
+		BranchType:      d.Get("branch_type").(string),
+		Pattern:         d.Get("pattern").(string),
+		Value:           d.Get("value").(int),
+		Users:           users,
+		Groups:          groups,
 	}
 }
 
+// resourceBranchRestrictionsCustomizeDiff checks the branches are picked the way branch_match_kind says,
+// by a pattern or by a branch type of the branching model.
+func resourceBranchRestrictionsCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	switch d.Get("branch_match_kind").(string) {
+	case "glob":
+		if d.Get("branch_type").(string) != "" {
+			return fmt.Errorf("branch_type is only used when branch_match_kind is branching_model, use pattern")
+		}
+	case "branching_model":
+		if d.Get("pattern").(string) != "" {
+			return fmt.Errorf("pattern is only used when branch_match_kind is glob, use branch_type")
+		}
+		if d.NewValueKnown("branch_type") && d.Get("branch_type").(string) == "" {
+			return fmt.Errorf("branch_type must be set when branch_match_kind is branching_model")
+		}
+	}
+
+	return nil
+}
+
 func resourceBranchRestrictionsCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	branchRestriction := createBranchRestriction(d)
-
-	bytedata, err := json.Marshal(branchRestriction)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
 
-	if err != nil {
+	if _, err := client.ownerFor(d); err != nil {
 		return err
 	}
 
-	branchRestrictionReq, err := client.Post(fmt.Sprintf("2.0/repositories/%s/%s/branch-restrictions",
+	branchRestriction, err := client.BranchRestrictions.Create(
+		ctx,
 		d.Get("owner").(string),
 		d.Get("repository").(string),
-	), bytes.NewBuffer(bytedata))
-
+		createBranchRestriction(d),
+	)
 	if err != nil {
 		return err
 	}
 
-	body, readerr := ioutil.ReadAll(branchRestrictionReq.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	decodeerr := json.Unmarshal(body, &branchRestriction)
-	if decodeerr != nil {
-		return decodeerr
-	}
-
 	d.SetId(string(fmt.Sprintf("%v", branchRestriction.ID)))
 
 	return resourceBranchRestrictionsRead(d, m)
@@ -163,52 +193,58 @@ func resourceBranchRestrictionsCreate(d *schema.ResourceData, m interface{}) err
 
 func resourceBranchRestrictionsRead(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
 
-	branchRestrictionsReq, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/branch-restrictions/%s",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-		url.PathEscape(d.Id()),
-	))
+	branchRestriction, err := client.BranchRestrictions.Get(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
 
-	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	if err != nil {
+		return err
+	}
 
-	if branchRestrictionsReq.StatusCode == 200 {
-		var branchRestriction BranchRestriction
-		body, readerr := ioutil.ReadAll(branchRestrictionsReq.Body)
-		if readerr != nil {
-			return readerr
-		}
+	users := make([]interface{}, 0, len(branchRestriction.Users))
+	for _, user := range branchRestriction.Users {
+		users = append(users, user.Username)
+	}
 
-		decodeerr := json.Unmarshal(body, &branchRestriction)
-		if decodeerr != nil {
-			return decodeerr
-		}
+	groups := make([]interface{}, 0, len(branchRestriction.Groups))
+	for _, group := range branchRestriction.Groups {
+		groups = append(groups, map[string]interface{}{
+			"owner": group.Owner.Username,
+			"slug":  group.Slug,
+		})
+	}
 
-		d.SetId(string(fmt.Sprintf("%v", branchRestriction.ID)))
-		d.Set("kind", branchRestriction.Kind)
-		d.Set("pattern", branchRestriction.Pattern)
-		d.Set("value", branchRestriction.Value)
-		d.Set("users", branchRestriction.Users)
-		d.Set("groups", branchRestriction.Groups)
+	d.SetId(string(fmt.Sprintf("%v", branchRestriction.ID)))
+	d.Set("kind", branchRestriction.Kind)
+	if branchRestriction.BranchMatchKind != "" {
+		d.Set("branch_match_kind", branchRestriction.BranchMatchKind)
 	}
+	d.Set("branch_type", branchRestriction.BranchType)
+	d.Set("pattern", branchRestriction.Pattern)
+	d.Set("value", branchRestriction.Value)
+	d.Set("users", users)
+	d.Set("groups", groups)
 
 	return nil
 }
 
 func resourceBranchRestrictionsUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	branchRestriction := createBranchRestriction(d)
-	payload, err := json.Marshal(branchRestriction)
-	if err != nil {
-		return err
-	}
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
 
-	_, err = client.Put(fmt.Sprintf("2.0/repositories/%s/%s/branch-restrictions/%s",
+	_, err := client.BranchRestrictions.Update(
+		ctx,
 		d.Get("owner").(string),
 		d.Get("repository").(string),
-		url.PathEscape(d.Id()),
-	), bytes.NewBuffer(payload))
-
+		d.Id(),
+		createBranchRestriction(d),
+	)
 	if err != nil {
 		return err
 	}
@@ -218,29 +254,24 @@ func resourceBranchRestrictionsUpdate(d *schema.ResourceData, m interface{}) err
 
 func resourceBranchRestrictionsDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	_, err := client.Delete(fmt.Sprintf("2.0/repositories/%s/%s/branch-restrictions/%s",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-		url.PathEscape(d.Id()),
-	))
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
 
-	return err
+	return client.BranchRestrictions.Delete(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
 }
 
 func resourceBranchRestrictionsExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
 
 	if v := d.Id(); v != "" {
-		branchRestrictionsReq, err := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/branch-restrictions/%s",
-			d.Get("owner").(string),
-			d.Get("repository").(string),
-			url.PathEscape(d.Id()),
-		))
-		if err != nil {
-			panic(err)
+		_, err := client.BranchRestrictions.Get(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+		if api.IsNotFound(err) {
+			return false, nil
 		}
 
-		if branchRestrictionsReq.StatusCode != 200 {
+		if err != nil {
 			return false, err
 		}
 
@@ -249,3 +280,18 @@ func resourceBranchRestrictionsExists(d *schema.ResourceData, m interface{}) (bo
 
 	return false, nil
 }
+
+// resourceBranchRestrictionsImport adopts a branch restriction by an id like `owner/repository/id`, the
+// id is the number bitbucket gave the restriction.
+func resourceBranchRestrictionsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Expected an import id like owner/repository/id, got %q", d.Id())
+	}
+
+	d.Set("owner", parts[0])
+	d.Set("repository", parts[1])
+	d.SetId(parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
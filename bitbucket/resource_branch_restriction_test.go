@@ -1,16 +1,19 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"net/url"
 	"os"
 	"testing"
+
+	"terraform-provider-bitbucket/bitbucket/api"
 )
 
 func TestAccBitbucketBranchRestriction_basic(t *testing.T) {
-	var branchRestriction BranchRestriction
+	var branchRestriction api.BranchRestriction
 
 	testUser := os.Getenv("BITBUCKET_USERNAME")
 	testAccBitbucketBranchRestrictionConfig := fmt.Sprintf(`
@@ -37,10 +40,63 @@ func TestAccBitbucketBranchRestriction_basic(t *testing.T) {
 					testAccCheckBitbucketBranchRestrictionExists("bitbucket_branch_restriction.test_repo_branch_restriction", &branchRestriction),
 				),
 			},
+			{
+				ResourceName:      "bitbucket_branch_restriction.test_repo_branch_restriction",
+				ImportState:       true,
+				ImportStateIdFunc: testAccBitbucketBranchRestrictionImportID("bitbucket_branch_restriction.test_repo_branch_restriction"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBitbucketBranchRestriction_branchingModel(t *testing.T) {
+	var branchRestriction api.BranchRestriction
+
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketBranchRestrictionConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-branch-restriction-test"
+		}
+		resource "bitbucket_branch_restriction" "test_repo_branch_restriction" {
+			owner = "%s"
+			repository = "${bitbucket_repository.test_repo.name}"
+			kind = "require_approvals_to_merge"
+			branch_match_kind = "branching_model"
+			branch_type = "production"
+			value = 2
+		}
+	`, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketBranchRestrictionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketBranchRestrictionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketBranchRestrictionExists("bitbucket_branch_restriction.test_repo_branch_restriction", &branchRestriction),
+					resource.TestCheckResourceAttr("bitbucket_branch_restriction.test_repo_branch_restriction", "branch_type", "production"),
+					resource.TestCheckResourceAttr("bitbucket_branch_restriction.test_repo_branch_restriction", "value", "2"),
+				),
+			},
 		},
 	})
 }
 
+func testAccBitbucketBranchRestrictionImportID(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found %s", n)
+		}
+
+		return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], rs.Primary.ID), nil
+	}
+}
+
 func testAccCheckBitbucketBranchRestrictionDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*Client)
 	rs, ok := s.RootModule().Resources["bitbucket_branch_restriction.test_repo_branch_restriction"]
@@ -48,7 +104,7 @@ func testAccCheckBitbucketBranchRestrictionDestroy(s *terraform.State) error {
 		return fmt.Errorf("Not found %s", "bitbucket_branch_restriction.test_repo_branch_restriction")
 	}
 
-	response, err := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/branch-restrictions/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], url.PathEscape(rs.Primary.Attributes["id"])))
+	response, err := client.Get(context.Background(), fmt.Sprintf("2.0/repositories/%s/%s/branch-restrictions/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], url.PathEscape(rs.Primary.Attributes["id"])))
 
 	if err == nil {
 		return fmt.Errorf("The resource was found should have errored")
@@ -61,7 +117,7 @@ func testAccCheckBitbucketBranchRestrictionDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketBranchRestrictionExists(n string, branchRestriction *BranchRestriction) resource.TestCheckFunc {
+func testAccCheckBitbucketBranchRestrictionExists(n string, branchRestriction *api.BranchRestriction) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -0,0 +1,55 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketBranch_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketBranchConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-branch-test"
+		}
+		resource "bitbucket_branch" "test_branch" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			name = "release/1.0"
+			target = "master"
+		}
+	`, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketBranchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketBranchConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_branch.test_branch", "id", testUser+"/test-repo-for-branch-test/release/1.0"),
+					resource.TestCheckResourceAttrSet("bitbucket_branch.test_branch", "hash"),
+				),
+			},
+			{
+				ResourceName:            "bitbucket_branch.test_branch",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"target"},
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketBranchDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_branch.test_branch"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_branch.test_branch")
+	}
+	return nil
+}
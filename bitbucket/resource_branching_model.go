@@ -0,0 +1,273 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceBranchingModel() *schema.Resource {
+	s := branchingModelSchema()
+	s["owner"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+	}
+	s["repository"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	return &schema.Resource{
+		Create: resourceBranchingModelCreate,
+		Read:   resourceBranchingModelRead,
+		Update: resourceBranchingModelUpdate,
+		Delete: resourceBranchingModelDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: s,
+	}
+}
+
+// branchingModelSchema are the settings of a branching model, repositories and projects share them. What
+// isn't configured is left as it is in bitbucket.
+func branchingModelSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"development": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"use_mainbranch": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
+		"production": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"enabled": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"use_mainbranch": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
+		"branch_type": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"kind": {
+						Type:     schema.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							"feature",
+							"bugfix",
+							"release",
+							"hotfix",
+						},
+							false),
+					},
+					"enabled": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+					"prefix": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// branchingModelFromResource builds the settings to send, only the configured ones are part of it
+func branchingModelFromResource(d *schema.ResourceData) *api.BranchingModel {
+	model := &api.BranchingModel{}
+
+	if v, ok := d.GetOk("development"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		development := v.([]interface{})[0].(map[string]interface{})
+		model.Development = &api.BranchingModelBranch{
+			Name:          development["name"].(string),
+			UseMainbranch: development["use_mainbranch"].(bool),
+		}
+	}
+
+	if v, ok := d.GetOk("production"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		production := v.([]interface{})[0].(map[string]interface{})
+		enabled := production["enabled"].(bool)
+		model.Production = &api.BranchingModelBranch{
+			Name:          production["name"].(string),
+			UseMainbranch: production["use_mainbranch"].(bool),
+			Enabled:       &enabled,
+		}
+	}
+
+	if v, ok := d.GetOk("branch_type"); ok {
+		for _, item := range v.(*schema.Set).List() {
+			branchType := item.(map[string]interface{})
+			model.BranchTypes = append(model.BranchTypes, api.BranchType{
+				Kind:    branchType["kind"].(string),
+				Enabled: branchType["enabled"].(bool),
+				Prefix:  branchType["prefix"].(string),
+			})
+		}
+	}
+
+	return model
+}
+
+// setBranchingModel saves the settings bitbucket returned on the resource
+func setBranchingModel(d *schema.ResourceData, model *api.BranchingModel) {
+	if model.Development != nil {
+		d.Set("development", []interface{}{map[string]interface{}{
+			"name":           model.Development.Name,
+			"use_mainbranch": model.Development.UseMainbranch,
+		}})
+	}
+
+	if model.Production != nil {
+		d.Set("production", []interface{}{map[string]interface{}{
+			"enabled":        model.Production.Enabled != nil && *model.Production.Enabled,
+			"name":           model.Production.Name,
+			"use_mainbranch": model.Production.UseMainbranch,
+		}})
+	}
+
+	branchTypes := make([]interface{}, 0, len(model.BranchTypes))
+	for _, branchType := range model.BranchTypes {
+		branchTypes = append(branchTypes, map[string]interface{}{
+			"kind":    branchType.Kind,
+			"enabled": branchType.Enabled,
+			"prefix":  branchType.Prefix,
+		})
+	}
+	d.Set("branch_type", branchTypes)
+}
+
+// defaultBranchingModel is what bitbucket starts every repository and project with, deleting the
+// resource goes back to it.
+func defaultBranchingModel() *api.BranchingModel {
+	disabled := false
+	return &api.BranchingModel{
+		Development: &api.BranchingModelBranch{UseMainbranch: true},
+		Production:  &api.BranchingModelBranch{UseMainbranch: false, Enabled: &disabled},
+		BranchTypes: []api.BranchType{
+			{Kind: "feature", Enabled: true, Prefix: "feature/"},
+			{Kind: "bugfix", Enabled: true, Prefix: "bugfix/"},
+			{Kind: "release", Enabled: true, Prefix: "release/"},
+			{Kind: "hotfix", Enabled: true, Prefix: "hotfix/"},
+		},
+	}
+}
+
+func resourceBranchingModelCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.BranchingModel.Update(ctx, owner, d.Get("repository").(string), branchingModelFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", owner, d.Get("repository").(string)))
+	return resourceBranchingModelRead(d, m)
+}
+
+func resourceBranchingModelRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/repository`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("repository", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	model, err := client.BranchingModel.Get(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	setBranchingModel(d, model)
+	return nil
+}
+
+func resourceBranchingModelUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	_, err := client.BranchingModel.Update(ctx, d.Get("owner").(string), d.Get("repository").(string), branchingModelFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceBranchingModelRead(d, m)
+}
+
+func resourceBranchingModelDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	_, err := client.BranchingModel.Update(ctx, d.Get("owner").(string), d.Get("repository").(string), defaultBranchingModel())
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
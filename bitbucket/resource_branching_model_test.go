@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketBranchingModel_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketBranchingModelConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-branching-model-test"
+		}
+		resource "bitbucket_branching_model" "test_model" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+
+			development {
+				use_mainbranch = true
+			}
+
+			branch_type {
+				kind = "feature"
+				prefix = "feat/"
+			}
+
+			branch_type {
+				kind = "hotfix"
+				enabled = false
+			}
+		}
+	`, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketBranchingModelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketBranchingModelConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_branching_model.test_model", "development.0.use_mainbranch", "true"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_branching_model.test_model",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketBranchingModelDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_branching_model.test_model"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_branching_model.test_model")
+	}
+	return nil
+}
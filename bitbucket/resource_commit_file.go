@@ -0,0 +1,203 @@
+package bitbucket
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+// resourceCommitFile commits files to a branch, every change of them is a new commit. Destroying it
+// commits their removal.
+func resourceCommitFile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCommitFileCreate,
+		Read:   resourceCommitFileRead,
+		Update: resourceCommitFileUpdate,
+		Delete: resourceCommitFileDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"branch": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"message": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Committed by terraform",
+			},
+			"author": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"file": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"content": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// filesByPath turns the file blocks into their content keyed by their path
+func filesByPath(set *schema.Set) map[string]string {
+	files := make(map[string]string, set.Len())
+
+	for _, v := range set.List() {
+		file := v.(map[string]interface{})
+		files[file["path"].(string)] = file["content"].(string)
+	}
+
+	return files
+}
+
+func newSourceCommitFromResource(d *schema.ResourceData) *api.SourceCommit {
+	return &api.SourceCommit{
+		Branch:  d.Get("branch").(string),
+		Message: d.Get("message").(string),
+		Author:  d.Get("author").(string),
+		Files:   filesByPath(d.Get("file").(*schema.Set)),
+	}
+}
+
+func resourceCommitFileCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	hash, err := client.Source.Commit(ctx, owner, d.Get("repository").(string), newSourceCommitFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	d.Set("hash", hash)
+	d.SetId(fmt.Sprintf("%s/%s/%s", owner, d.Get("repository").(string), d.Get("branch").(string)))
+
+	return resourceCommitFileRead(d, m)
+}
+
+func resourceCommitFileRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	owner := d.Get("owner").(string)
+	repository := d.Get("repository").(string)
+	branch := d.Get("branch").(string)
+
+	// The files are read as of the head of the branch, a file changed or removed by a later commit is
+	// committed again
+	files := make([]interface{}, 0)
+	for path := range filesByPath(d.Get("file").(*schema.Set)) {
+		content, err := client.Source.GetFile(ctx, owner, repository, branch, path)
+		if api.IsNotFound(err) {
+			log.Printf("[DEBUG] %s is not on %s of %s/%s anymore", path, branch, owner, repository)
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		files = append(files, map[string]interface{}{
+			"path":    path,
+			"content": content,
+		})
+	}
+
+	if len(files) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("file", files)
+
+	return nil
+}
+
+func resourceCommitFileUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	commit := newSourceCommitFromResource(d)
+
+	// Files that are not configured anymore are removed by the same commit
+	old, _ := d.GetChange("file")
+	for path := range filesByPath(old.(*schema.Set)) {
+		if _, ok := commit.Files[path]; !ok {
+			commit.Deleted = append(commit.Deleted, path)
+		}
+	}
+
+	hash, err := client.Source.Commit(ctx, d.Get("owner").(string), d.Get("repository").(string), commit)
+	if err != nil {
+		return err
+	}
+
+	d.Set("hash", hash)
+
+	return resourceCommitFileRead(d, m)
+}
+
+func resourceCommitFileDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	commit := &api.SourceCommit{
+		Branch:  d.Get("branch").(string),
+		Message: d.Get("message").(string),
+		Author:  d.Get("author").(string),
+	}
+	for path := range filesByPath(d.Get("file").(*schema.Set)) {
+		commit.Deleted = append(commit.Deleted, path)
+	}
+
+	_, err := client.Source.Commit(ctx, d.Get("owner").(string), d.Get("repository").(string), commit)
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketCommitFile_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketCommitFileConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-commit-file-test"
+		}
+		resource "bitbucket_commit_file" "test_files" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			branch = "master"
+			message = "Bootstrap the repository"
+			file {
+				path = "README.md"
+				content = "# Illusions"
+			}
+			file {
+				path = "docs/CODEOWNERS"
+				content = "* @gob"
+			}
+		}
+	`, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketCommitFileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketCommitFileConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_commit_file.test_files", "file.#", "2"),
+					resource.TestCheckResourceAttrSet("bitbucket_commit_file.test_files", "hash"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketCommitFileConfig, `content = "# Illusions"`, `content = "# Illusions, not tricks"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_commit_file.test_files", "file.#", "2"),
+					resource.TestCheckResourceAttrSet("bitbucket_commit_file.test_files", "hash"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketCommitFileDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_commit_file.test_files"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_commit_file.test_files")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceDefaultBranch() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDefaultBranchCreate,
+		Read:   resourceDefaultBranchRead,
+		Update: resourceDefaultBranchUpdate,
+		Delete: resourceDefaultBranchDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"branch": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceDefaultBranchCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	err = client.Repositories.UpdateMainBranch(ctx, owner, d.Get("repository").(string), d.Get("branch").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", owner, d.Get("repository").(string)))
+	return resourceDefaultBranchRead(d, m)
+}
+
+func resourceDefaultBranchRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/repository`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("repository", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	repo, err := client.Repositories.Get(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if repo.MainBranch != nil {
+		d.Set("branch", repo.MainBranch.Name)
+	}
+
+	return nil
+}
+
+func resourceDefaultBranchUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	err := client.Repositories.UpdateMainBranch(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Get("branch").(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceDefaultBranchRead(d, m)
+}
+
+// resourceDefaultBranchDelete only forgets the main branch, a repository always has one
+func resourceDefaultBranchDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
@@ -0,0 +1,58 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketDefaultBranch_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketDefaultBranchConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-default-branch-test"
+		}
+		resource "bitbucket_branch" "develop" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			name = "develop"
+			target = "master"
+		}
+		resource "bitbucket_default_branch" "test_default_branch" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			branch = bitbucket_branch.develop.name
+		}
+	`, testUser, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketDefaultBranchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketDefaultBranchConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_default_branch.test_default_branch", "branch", "develop"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_default_branch.test_default_branch",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketDefaultBranchDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_default_branch.test_default_branch"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_default_branch.test_default_branch")
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceDeployKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDeployKeyCreate,
+		Read:   resourceDeployKeyRead,
+		Update: resourceDeployKeyUpdate,
+		Delete: resourceDeployKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDeployKeyImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"key": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressPublicKeyComment,
+			},
+			"label": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// publicKeyWithoutComment is the type and body of an OpenSSH public key, bitbucket drops the comment
+// from the keys it serves.
+func publicKeyWithoutComment(key string) string {
+	fields := strings.Fields(key)
+	if len(fields) > 2 {
+		fields = fields[:2]
+	}
+	return strings.Join(fields, " ")
+}
+
+// suppressPublicKeyComment ignores the comment and whitespace of public keys
+func suppressPublicKeyComment(k, old, new string, d *schema.ResourceData) bool {
+	return publicKeyWithoutComment(old) == publicKeyWithoutComment(new)
+}
+
+func newDeployKeyFromResource(d *schema.ResourceData) *api.DeployKey {
+	return &api.DeployKey{
+		Key:   d.Get("key").(string),
+		Label: d.Get("label").(string),
+	}
+}
+
+func resourceDeployKeyCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	key, err := client.DeployKeys.Create(ctx, owner, d.Get("repository").(string), newDeployKeyFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(key.ID))
+
+	return resourceDeployKeyRead(d, m)
+}
+
+func resourceDeployKeyRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	key, err := client.DeployKeys.Get(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Keep the key as configured when only the comment bitbucket dropped differs
+	if publicKeyWithoutComment(key.Key) != publicKeyWithoutComment(d.Get("key").(string)) {
+		d.Set("key", key.Key)
+	}
+	d.Set("label", key.Label)
+	d.Set("comment", key.Comment)
+
+	return nil
+}
+
+func resourceDeployKeyUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	_, err := client.DeployKeys.Update(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id(), newDeployKeyFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceDeployKeyRead(d, m)
+}
+
+func resourceDeployKeyDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.DeployKeys.Delete(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// resourceDeployKeyImport adopts a deploy key by an id like `owner/repository/id`, the id is the number
+// bitbucket gave the key.
+func resourceDeployKeyImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Expected an import id like owner/repository/id, got %q", d.Id())
+	}
+
+	d.Set("owner", parts[0])
+	d.Set("repository", parts[1])
+	d.SetId(parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
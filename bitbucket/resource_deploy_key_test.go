@@ -0,0 +1,89 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testAccPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPzqXskH50oYDBgozIFlqmczIBW4akm4qSM23n97s+ec"
+
+func TestAccBitbucketDeployKey_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketDeployKeyConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-deploy-key-test"
+		}
+		resource "bitbucket_deploy_key" "test_key" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			key = "%s ci@example.com"
+			label = "ci"
+		}
+	`, testUser, testUser, testAccPublicKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketDeployKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketDeployKeyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_deploy_key.test_key", "label", "ci"),
+					resource.TestCheckResourceAttr("bitbucket_deploy_key.test_key", "comment", "ci@example.com"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketDeployKeyConfig, `label = "ci"`, `label = "build"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_deploy_key.test_key", "label", "build"),
+				),
+			},
+			{
+				ResourceName:            "bitbucket_deploy_key.test_key",
+				ImportState:             true,
+				ImportStateIdPrefix:     testUser + "/test-repo-for-deploy-key-test/",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketDeployKeyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	rs, ok := s.RootModule().Resources["bitbucket_deploy_key.test_key"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_deploy_key.test_key")
+	}
+
+	response, _ := client.Get(context.Background(), fmt.Sprintf("2.0/repositories/%s/%s/deploy-keys/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], rs.Primary.ID))
+
+	if response.StatusCode != 404 {
+		return fmt.Errorf("Deploy key still exists")
+	}
+
+	return nil
+}
+
+func TestPublicKeyWithoutComment(t *testing.T) {
+	cases := map[string]string{
+		testAccPublicKey:                                testAccPublicKey,
+		testAccPublicKey + " ci@example.com":            testAccPublicKey,
+		"  " + testAccPublicKey + " a b\n":              testAccPublicKey,
+		strings.Replace(testAccPublicKey, " ", "\t", 1): testAccPublicKey,
+	}
+
+	for key, expected := range cases {
+		if got := publicKeyWithoutComment(key); got != expected {
+			t.Errorf("publicKeyWithoutComment(%q) = %q, expected %q", key, got, expected)
+		}
+	}
+}
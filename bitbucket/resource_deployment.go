@@ -1,40 +1,14 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"net/http"
-	"net/url"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
-)
-
-// Deployment structure for handling key info
-type Deployment struct {
-	Name  string `json:"name"`
-	Stage *Stage `json:"environment_type"`
-	UUID  string `json:"uuid,omitempty"`
-}
-
-type Stage struct {
-	Name string `json:"name"`
-}
 
-type Values struct {
-	Values []Value `json:"values"`
-}
-
-type Value struct {
-	Category Category `json:"category"`
-	UUID     string   `json:"uuid,omitempty"`
-}
-type Category struct {
-	Name string `json:"name"`
-}
+	"terraform-provider-bitbucket/bitbucket/api"
+)
 
 func resourceDeployment() *schema.Resource {
 	return &schema.Resource{
@@ -42,6 +16,15 @@ func resourceDeployment() *schema.Resource {
 		Update: resourceDeploymentUpdate,
 		Read:   resourceDeploymentRead,
 		Delete: resourceDeploymentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDeploymentImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"uuid": {
@@ -55,6 +38,8 @@ func resourceDeployment() *schema.Resource {
 			"stage": {
 				Type:     schema.TypeString,
 				Required: true,
+				// Bitbucket can't change the type of an environment
+				ForceNew: true,
 				ValidateFunc: validation.StringInSlice([]string{
 					"Test",
 					"Staging",
@@ -62,20 +47,33 @@ func resourceDeployment() *schema.Resource {
 				},
 					false),
 			},
+			"rank": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"admin_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"repository": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 		},
 	}
 }
 
-func newDeploymentFromResource(d *schema.ResourceData) *Deployment {
-	dk := &Deployment{
+func newDeploymentFromResource(d *schema.ResourceData) *api.Deployment {
+	dk := &api.Deployment{
 		Name: d.Get("name").(string),
-		Stage: &Stage{
+		Stage: &api.Stage{
 			Name: d.Get("stage").(string),
 		},
+		Rank: d.Get("rank").(int),
 	}
 	return dk
 }
@@ -88,66 +86,61 @@ func resourceDeploymentCreate(d *schema.ResourceData, m interface{}) error {
 
 	if !exists {
 		client := m.(*Client)
-		rvcr := newDeploymentFromResource(d)
-		bytedata, err := json.Marshal(rvcr)
-		if err != nil {
-			return err
-		}
+		ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+		defer cancel()
 
-		req, err := client.Post(fmt.Sprintf("2.0/repositories/%s/environments/",
-			d.Get("repository").(string),
-		), bytes.NewBuffer(bytedata))
+		owner, slug := client.splitRepository(d.Get("repository").(string))
+
+		deployment, err := client.Deployments.Create(ctx, owner, slug, newDeploymentFromResource(d))
 		if err != nil {
 			return err
 		}
 
-		var deployment Deployment
-
-		body, readerr := ioutil.ReadAll(req.Body)
-		if readerr != nil {
-			return readerr
-		}
-
-		decodeerr := json.Unmarshal(body, &deployment)
-		if decodeerr != nil {
-			return decodeerr
-		}
 		d.Set("uuid", deployment.UUID)
 		d.SetId(fmt.Sprintf("%s:%s", d.Get("repository"), deployment.UUID))
 
+		// Restrictions can only be changed once the environment exists
+		if d.Get("admin_only").(bool) {
+			return resourceDeploymentUpdate(d, m)
+		}
+
 		return resourceDeploymentRead(d, m)
 	}
 	return nil
 }
 
+// checkIfNameAlreadyExists adopts the environment of the repository with the same name, ignoring case.
+// Bitbucket creates Test, Staging and Production for every repository and refuses a second environment
+// with the name of an existing one.
 func checkIfNameAlreadyExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	exists := false
 	name := d.Get("name").(string)
 	client := m.(*Client)
-	req, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/environments/", d.Get("repository").(string)))
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
 
-	if req.StatusCode == http.StatusOK {
-		var values Values
-		body, err := ioutil.ReadAll(req.Body)
-		if err != nil {
-			return false, err
-		}
+	owner, slug := client.splitRepository(d.Get("repository").(string))
 
-		err = json.Unmarshal(body, &values)
-		if err != nil {
-			return false, err
-		}
+	deployments, err := client.Deployments.List(ctx, owner, slug)
+	if err != nil && !api.IsNotFound(err) {
+		return false, err
+	}
 
-		for _, x := range values.Values {
-			if name == x.Category.Name {
-				exists = true
-				d.Set("uuid", x.UUID)
-				d.SetId(fmt.Sprintf("%s:%s", d.Get("repository"), x.UUID))
-			}
+	differs := false
+	for _, x := range deployments {
+		if strings.EqualFold(name, x.Name) {
+			exists = true
+			rank, rankSet := d.GetOk("rank")
+			differs = x.Name != name || (rankSet && rank.(int) != x.Rank) || adminOnly(&x) != d.Get("admin_only").(bool)
+			d.Set("uuid", x.UUID)
+			d.SetId(fmt.Sprintf("%s:%s", d.Get("repository"), x.UUID))
 		}
 	}
 
 	if exists {
+		if differs {
+			return true, resourceDeploymentUpdate(d, m)
+		}
 		return true, resourceDeploymentRead(d, m)
 	}
 	return false, nil
@@ -156,33 +149,27 @@ func checkIfNameAlreadyExists(d *schema.ResourceData, m interface{}) (bool, erro
 func resourceDeploymentRead(d *schema.ResourceData, m interface{}) error {
 
 	client := m.(*Client)
-	req, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/environments/%s",
-		d.Get("repository").(string),
-		d.Get("uuid").(string),
-	))
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
 
-	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	owner, slug := client.splitRepository(d.Get("repository").(string))
 
-	if req.StatusCode == 200 {
-		var Deployment Deployment
-		body, err := ioutil.ReadAll(req.Body)
-		if err != nil {
-			return err
-		}
-
-		err = json.Unmarshal(body, &Deployment)
-		if err != nil {
-			return err
-		}
+	deployment, err := client.Deployments.Get(ctx, owner, slug, d.Get("uuid").(string))
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
 
-		d.Set("uuid", Deployment.UUID)
-		d.Set("name", Deployment.Name)
-		d.Set("stage", Deployment.Stage.Name)
+	if err != nil {
+		return err
 	}
 
-	if req.StatusCode == 404 {
-		d.SetId("")
-		return nil
+	d.Set("uuid", deployment.UUID)
+	d.Set("name", deployment.Name)
+	d.Set("rank", deployment.Rank)
+	d.Set("admin_only", adminOnly(deployment))
+	if deployment.Stage != nil {
+		d.Set("stage", deployment.Stage.Name)
 	}
 
 	return nil
@@ -190,32 +177,65 @@ func resourceDeploymentRead(d *schema.ResourceData, m interface{}) error {
 
 func resourceDeploymentUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	rvcr := newDeploymentFromResource(d)
-	bytedata, err := json.Marshal(rvcr)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
 
-	if err != nil {
-		return err
+	owner, slug := client.splitRepository(d.Get("repository").(string))
+	uuid := d.Get("uuid").(string)
+
+	change := &api.DeploymentChange{
+		Name:         d.Get("name").(string),
+		Rank:         d.Get("rank").(int),
+		Restrictions: &api.Restrictions{AdminOnly: d.Get("admin_only").(bool)},
 	}
-	req, err := client.Put(fmt.Sprintf("2.0/repositories/%s/environments/%s",
-		d.Get("repository").(string),
-		d.Get("uuid").(string),
-	), bytes.NewBuffer(bytedata))
+
+	err := client.Deployments.Update(ctx, owner, slug, uuid, change)
 	if err != nil {
 		return err
 	}
 
-	if req.StatusCode != 200 {
-		return nil
+	// The change is applied in the background, wait until bitbucket serves it
+	err = client.waitUntilVisible(ctx, "change of deployment "+uuid, func() (bool, error) {
+		deployment, err := client.Deployments.Get(ctx, owner, slug, uuid)
+		if err != nil {
+			return false, err
+		}
+		return deployment.Name == change.Name &&
+			(change.Rank == 0 || deployment.Rank == change.Rank) &&
+			adminOnly(deployment) == change.Restrictions.AdminOnly, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	return resourceDeploymentRead(d, m)
 }
 
+// adminOnly tells whether only admins may deploy to the environment
+func adminOnly(deployment *api.Deployment) bool {
+	return deployment.Restrictions != nil && deployment.Restrictions.AdminOnly
+}
+
 func resourceDeploymentDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	_, err := client.Delete(fmt.Sprintf("2.0/repositories/%s/environments/%s",
-		d.Get("repository").(string),
-		d.Get("uuid").(string),
-	))
-	return err
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	owner, slug := client.splitRepository(d.Get("repository").(string))
+
+	return client.Deployments.Delete(ctx, owner, slug, d.Get("uuid").(string))
+}
+
+// resourceDeploymentImport adopts an environment by an id like `owner/slug:environment_uuid`
+func resourceDeploymentImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	repository, uuid := parseDeploymentId(d.Id())
+	if repository == "" || uuid == "" {
+		return nil, fmt.Errorf("Expected an import id like owner/slug:{environment-uuid}, got %q", d.Id())
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", repository, withBraces(uuid)))
+	d.Set("repository", repository)
+	d.Set("uuid", withBraces(uuid))
+
+	return []*schema.ResourceData{d}, nil
 }
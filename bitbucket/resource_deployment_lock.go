@@ -0,0 +1,161 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+// resourceDeploymentLock closes the lock of a deployment environment for as long as it exists, like during
+// a change freeze. Destroying it opens the lock again.
+func resourceDeploymentLock() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDeploymentLockCreate,
+		Read:   resourceDeploymentLockRead,
+		Delete: resourceDeploymentLockDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDeploymentLockImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"environment": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressSameEnvironment,
+			},
+			"environment_uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"environment_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// environmentLocked tells whether deployments to the environment are held
+func environmentLocked(deployment *api.Deployment) bool {
+	return deployment.Lock != nil && deployment.Lock.Name == api.LockClosed
+}
+
+// setDeploymentLock changes the lock of the environment and waits until bitbucket serves the change
+func (c *Client) setDeploymentLock(ctx context.Context, owner, slug, uuid string, locked bool) error {
+	lock := api.LockOpen
+	if locked {
+		lock = api.LockClosed
+	}
+
+	err := c.Deployments.Update(ctx, owner, slug, uuid, &api.DeploymentChange{Lock: &api.Lock{Name: lock}})
+	if err != nil {
+		return err
+	}
+
+	return c.waitUntilVisible(ctx, "lock of deployment "+uuid, func() (bool, error) {
+		deployment, err := c.Deployments.Get(ctx, owner, slug, uuid)
+		if err != nil {
+			return false, err
+		}
+		return environmentLocked(deployment) == locked, nil
+	})
+}
+
+func resourceDeploymentLockCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	repository := d.Get("repository").(string)
+	environment := d.Get("environment").(string)
+	owner, slug := client.splitRepository(repository)
+
+	uuid, ok, err := client.resolveEnvironment(ctx, owner, slug, environment)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s has no deployment environment named %q", repository, environment)
+	}
+
+	d.Set("environment_uuid", uuid)
+	if err := client.setDeploymentLock(ctx, owner, slug, uuid, true); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", repository, uuid))
+
+	return resourceDeploymentLockRead(d, m)
+}
+
+func resourceDeploymentLockRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	owner, slug := client.splitRepository(d.Get("repository").(string))
+
+	deployment, err := client.Deployments.Get(ctx, owner, slug, d.Get("environment_uuid").(string))
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// A lock opened outside of terraform is closed again by the next apply
+	if !environmentLocked(deployment) {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("environment_uuid", deployment.UUID)
+	d.Set("environment_name", deployment.Name)
+
+	return nil
+}
+
+func resourceDeploymentLockDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	owner, slug := client.splitRepository(d.Get("repository").(string))
+
+	err := client.setDeploymentLock(ctx, owner, slug, d.Get("environment_uuid").(string), false)
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// resourceDeploymentLockImport adopts the lock of an environment by an id like `owner/slug:environment_uuid`
+func resourceDeploymentLockImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	repository, uuid := parseDeploymentId(d.Id())
+	if repository == "" || uuid == "" {
+		return nil, fmt.Errorf("Expected an import id like owner/slug:{environment-uuid}, got %q", d.Id())
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", repository, withBraces(uuid)))
+	d.Set("repository", repository)
+	d.Set("environment", withBraces(uuid))
+	d.Set("environment_uuid", withBraces(uuid))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,70 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func TestAccBitbucketDeploymentLock_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketDeploymentLockConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-deployment-lock-test"
+			pipelines_enabled = true
+		}
+		resource "bitbucket_deployment_lock" "test_lock" {
+			repository = bitbucket_repository.test_repo.id
+			environment = "Production"
+		}
+	`, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketDeploymentLockDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketDeploymentLockConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucket_deployment_lock.test_lock", "environment_uuid"),
+				),
+			},
+			{
+				ResourceName:            "bitbucket_deployment_lock.test_lock",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"environment"},
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketDeploymentLockDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	rs, ok := s.RootModule().Resources["bitbucket_deployment_lock.test_lock"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_deployment_lock.test_lock")
+	}
+
+	owner, slug := client.splitRepository(rs.Primary.Attributes["repository"])
+	deployment, err := client.Deployments.Get(context.Background(), owner, slug, rs.Primary.Attributes["environment_uuid"])
+	if api.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if environmentLocked(deployment) {
+		return fmt.Errorf("Deployment environment still locked")
+	}
+	return nil
+}
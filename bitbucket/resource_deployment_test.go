@@ -1,8 +1,10 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -12,17 +14,19 @@ import (
 func TestAccBitbucketDeployment_basic(t *testing.T) {
 	testUser := os.Getenv("BITBUCKET_USERNAME")
 	testAccBitbucketDeploymentConfig := fmt.Sprintf(`
-		resource "bitbucket_repository "test_repo" {
+		resource "bitbucket_repository" "test_repo" {
 			owner = "%s"
 			name = "test-repo-for-deployment-test"
 		}
 		resource "bitbucket_deployment" "test_deploy" {
 			name = "test_deploy"
-            stage = "Staging"
-            repository = bitbucket_repository.test_repo.id
+			stage = "Staging"
+			repository = bitbucket_repository.test_repo.id
 		}
 	`, testUser)
 
+	var uuid string
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -32,8 +36,23 @@ func TestAccBitbucketDeployment_basic(t *testing.T) {
 				Config: testAccBitbucketDeploymentConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckBitbucketDeploymentExists("bitbucket_deployment.test_deploy"),
+					testAccCheckBitbucketDeploymentUUID("bitbucket_deployment.test_deploy", &uuid),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketDeploymentConfig, `name = "test_deploy"`, `name = "test_deploy_renamed"
+			rank = 2`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_deployment.test_deploy", "name", "test_deploy_renamed"),
+					resource.TestCheckResourceAttr("bitbucket_deployment.test_deploy", "rank", "2"),
+					testAccCheckBitbucketDeploymentUUID("bitbucket_deployment.test_deploy", &uuid),
 				),
 			},
+			{
+				ResourceName:      "bitbucket_deployment.test_deploy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -45,7 +64,7 @@ func testAccCheckBitbucketDeploymentDestroy(s *terraform.State) error {
 		return fmt.Errorf("Not found %s", "bitbucket_deployment.test_deploy")
 	}
 
-	response, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["name"]))
+	response, _ := client.Get(context.Background(), fmt.Sprintf("2.0/repositories/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["name"]))
 
 	if response.StatusCode != 404 {
 		return fmt.Errorf("Deployment still exists")
@@ -66,3 +85,21 @@ func testAccCheckBitbucketDeploymentExists(n string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+// testAccCheckBitbucketDeploymentUUID remembers the uuid of the environment in uuid and fails when it
+// changed since, an environment that was changed in place keeps it.
+func testAccCheckBitbucketDeploymentUUID(n string, uuid *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found %s", n)
+		}
+
+		if *uuid != "" && rs.Primary.Attributes["uuid"] != *uuid {
+			return fmt.Errorf("Expected the environment to keep the uuid %s, got %s", *uuid, rs.Primary.Attributes["uuid"])
+		}
+		*uuid = rs.Primary.Attributes["uuid"]
+
+		return nil
+	}
+}
@@ -1,33 +1,16 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net/url"
+	"regexp"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
-)
-
-// DeploymentVariable structure for handling key info
-type DeploymentVariable struct {
-	Key     string `json:"key"`
-	Value   string `json:"value"`
-	UUID    string `json:"uuid,omitempty"`
-	Secured bool   `json:"secured"`
-}
 
-// PaginatedReviewers is a paginated list that the bitbucket api returns
-type PaginatedDeploymentVariables struct {
-	Values []DeploymentVariable `json:"values,omitempty"`
-	Page   int                  `json:"page,omitempty"`
-	Size   int                  `json:"size,omitempty"`
-	Next   string               `json:"next,omitempty"`
-}
+	"terraform-provider-bitbucket/bitbucket/api"
+)
 
 func resourceDeploymentVariable() *schema.Resource {
 	return &schema.Resource{
@@ -35,6 +18,16 @@ func resourceDeploymentVariable() *schema.Resource {
 		Update: resourceDeploymentVariableUpdate,
 		Read:   resourceDeploymentVariableRead,
 		Delete: resourceDeploymentVariableDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDeploymentVariableImport,
+		},
+		CustomizeDiff: resourceDeploymentVariableCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"uuid": {
@@ -42,28 +35,71 @@ func resourceDeploymentVariable() *schema.Resource {
 				Computed: true,
 			},
 			"key": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateVariableKey,
 			},
 			"value": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
 			},
 			"secured": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"repository": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"deployment"},
+			},
+			"environment": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"deployment"},
+				DiffSuppressFunc: suppressSameEnvironment,
+			},
+			"environment_uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"environment_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"deployment": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Deprecated:    "Use repository and environment instead",
+				ValidateFunc:  validateDeploymentId,
+				ConflictsWith: []string{"repository", "environment"},
+			},
+			"force_update": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+			},
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceDeploymentVariableV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDeploymentVariableStateUpgradeV0,
+				Version: 0,
 			},
 		},
 	}
 }
 
-func newDeploymentVariableFromResource(d *schema.ResourceData) *DeploymentVariable {
-	dv := &DeploymentVariable{
+func newDeploymentVariableFromResource(d *schema.ResourceData) *api.DeploymentVariable {
+	dv := &api.DeploymentVariable{
 		Key:     d.Get("key").(string),
 		Value:   d.Get("value").(string),
 		Secured: d.Get("secured").(bool),
@@ -73,123 +109,371 @@ func newDeploymentVariableFromResource(d *schema.ResourceData) *DeploymentVariab
 
 func parseDeploymentId(str string) (repository string, deployment string) {
 	parts := strings.SplitN(str, ":", 2)
+	if len(parts) != 2 {
+		return parts[0], ""
+	}
 	return parts[0], parts[1]
 }
 
-func resourceDeploymentVariableCreate(d *schema.ResourceData, m interface{}) error {
-	var rv DeploymentVariable
+// validateDeploymentId checks deployment is the id of a bitbucket_deployment, `repository:environment`
+func validateDeploymentId(v interface{}, k string) (ws []string, errors []error) {
+	if repository, environment := parseDeploymentId(v.(string)); repository == "" || environment == "" {
+		errors = append(errors, fmt.Errorf("%q must be the id of a bitbucket_deployment like owner/slug:{environment-uuid}, got %q", k, v))
+	}
+	return
+}
+
+// resourceGetter is what ResourceData and ResourceDiff have in common
+type resourceGetter interface {
+	Get(key string) interface{}
+	HasChange(key string) bool
+}
+
+// deploymentVariableTarget is the repository and the uuid of the environment a variable belongs to. They
+// are either set on their own or through the deprecated `repository:environment` of deployment.
+func deploymentVariableTarget(d resourceGetter) (repository string, environment string) {
+	repository, environment = d.Get("repository").(string), d.Get("environment").(string)
+
+	if deployment := d.Get("deployment").(string); deployment != "" && (repository == "" || environment == "" || d.HasChange("deployment")) {
+		return parseDeploymentId(deployment)
+	}
+
+	return repository, environment
+}
+
+// suppressSameEnvironment ignores switching between the name and the uuid of the same environment either
+// way, a variable is only moved to another environment by recreating it, a PUT to another environment
+// silently does nothing. The old value always names the environment of environment_uuid, it is what
+// Read resolved.
+func suppressSameEnvironment(k, old, new string, d *schema.ResourceData) bool {
+	if strings.EqualFold(old, new) {
+		return true
+	}
+
+	uuid := d.Get("environment_uuid").(string)
+	if uuid == "" {
+		return false
+	}
+	if uuidPattern.MatchString(new) {
+		return withBraces(new) == uuid
+	}
+
+	name := d.Get("environment_name").(string)
+	return name != "" && strings.EqualFold(new, name)
+}
+
+// environmentName looks up the name of the environment with the given uuid, empty when it is unknown
+func (c *Client) environmentName(ctx context.Context, owner, slug, uuid string) (string, error) {
+	name, _, err := c.Deployments.NameByUUID(ctx, owner, slug, uuid)
+	return name, err
+}
+
+// uuidPattern matches uuids with or without the braces bitbucket puts around them
+var uuidPattern = regexp.MustCompile(`^\{?[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\}?$`)
+
+// resolveEnvironment returns the uuid of the environment of a variable, which can also be given by its
+// name. ok is false when the repository has no environment of that name.
+func (c *Client) resolveEnvironment(ctx context.Context, owner, slug, environment string) (uuid string, ok bool, err error) {
+	if uuidPattern.MatchString(environment) {
+		return withBraces(environment), true, nil
+	}
+
+	return c.Deployments.UUIDByName(ctx, owner, slug, environment)
+}
+
+// resourceDeploymentVariableCustomizeDiff fails the plan of a new or renamed variable when its environment
+// already has a variable with that key. Bitbucket accepts both and whichever is written last wins.
+func resourceDeploymentVariableCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("key") {
+		return nil
+	}
+
+	for _, key := range []string{"key", "repository", "environment", "deployment"} {
+		if !d.NewValueKnown(key) {
+			// Checked again on apply
+			return nil
+		}
+	}
+
+	repository, environment := deploymentVariableTarget(d)
+	if repository == "" || environment == "" {
+		return nil
+	}
+
 	client := m.(*Client)
-	rvcr := newDeploymentVariableFromResource(d)
-	bytedata, err := json.Marshal(rvcr)
-	if err != nil {
+	ctx := client.StopContext
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	owner, slug := client.splitRepository(repository)
+	deployment, ok, err := client.resolveEnvironment(ctx, owner, slug, environment)
+	if err != nil || !ok {
+		// An environment that does not exist yet has no variables
 		return err
 	}
 
-	repository, deployment := parseDeploymentId(d.Get("deployment").(string))
-	req, err := client.Post(fmt.Sprintf("2.0/repositories/%s/deployments_config/environments/%s/variables",
-		repository,
-		deployment,
-	), bytes.NewBuffer(bytedata))
+	return client.checkDuplicateKey(ctx, owner, slug, deployment, d.Get("key").(string), d.Get("uuid").(string))
+}
+
+// checkDuplicateKey fails when an environment has a variable other than the one with the given uuid whose
+// key only differs from key by case, if at all.
+func (c *Client) checkDuplicateKey(ctx context.Context, owner, slug, deployment, key, uuid string) error {
+	variables, err := c.DeploymentVariables.List(ctx, owner, slug, deployment)
 	if err != nil {
 		return err
 	}
 
-	body, err := ioutil.ReadAll(req.Body)
-	if err != nil {
+	for _, variable := range variables {
+		if variable.UUID != uuid && strings.EqualFold(variable.Key, key) {
+			return fmt.Errorf("The deployment environment %s of %s/%s already has the variable %s (%s), bitbucket would keep both and the last one written wins", deployment, owner, slug, variable.Key, variable.UUID)
+		}
+	}
+
+	return nil
+}
+
+// environmentGone tells whether the environment with the given uuid was deleted. Bitbucket answers a
+// listing of the variables of a deleted environment with no variables, just like for an environment that
+// has none yet.
+func (c *Client) environmentGone(ctx context.Context, owner, slug, deployment string) (bool, error) {
+	_, err := c.Deployments.Get(ctx, owner, slug, deployment)
+	if api.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// explainMissingEnvironment turns the 404 of a write to the variables of a deleted environment into an
+// error that says so, any other error is returned as is.
+func (c *Client) explainMissingEnvironment(ctx context.Context, owner, slug, deployment string, err error) error {
+	if !api.IsNotFound(err) {
 		return err
 	}
 
-	err = json.Unmarshal(body, &rv)
+	if gone, _ := c.environmentGone(ctx, owner, slug, deployment); gone {
+		return fmt.Errorf("The deployment environment %s of %s/%s does not exist, it was deleted or recreated with a new uuid: %s", deployment, owner, slug, err)
+	}
+	return err
+}
+
+func resourceDeploymentVariableCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	repository, environment := deploymentVariableTarget(d)
+	if repository == "" || environment == "" {
+		return fmt.Errorf("repository and environment must be set")
+	}
+	owner, slug := client.splitRepository(repository)
+
+	deployment, ok, err := client.resolveEnvironment(ctx, owner, slug, environment)
 	if err != nil {
 		return err
 	}
+	if !ok {
+		return fmt.Errorf("%s/%s has no deployment environment named %q", owner, slug, environment)
+	}
+
+	// Checked at plan time too, but another variable with the key may have been created since
+	if err := client.checkDuplicateKey(ctx, owner, slug, deployment, d.Get("key").(string), ""); err != nil {
+		return err
+	}
+
+	rv, err := client.DeploymentVariables.Create(ctx, owner, slug, deployment, newDeploymentVariableFromResource(d))
+	if err != nil {
+		return client.explainMissingEnvironment(ctx, owner, slug, deployment, err)
+	}
+
 	d.Set("uuid", rv.UUID)
 	d.SetId(rv.UUID)
 
-	time.Sleep(5000 * time.Millisecond) // sleep for a while, to allow BitBucket cache to catch up
+	err = client.waitUntilVisible(ctx, "deployment variable "+rv.UUID, func() (bool, error) {
+		_, err := client.DeploymentVariables.Get(ctx, owner, slug, deployment, rv.UUID)
+		if api.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err != nil {
+		return err
+	}
+
 	return resourceDeploymentVariableRead(d, m)
 }
 
 func resourceDeploymentVariableRead(d *schema.ResourceData, m interface{}) error {
 
-	repository, deployment := parseDeploymentId(d.Get("deployment").(string))
+	repository, environment := deploymentVariableTarget(d)
 	client := m.(*Client)
-	rvReq, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/deployments_config/environments/%s/variables",
-		repository,
-		deployment,
-	))
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
 
-	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	owner, slug := client.splitRepository(repository)
 
-	if rvReq.StatusCode == 200 {
-		var prv PaginatedDeploymentVariables
-		body, err := ioutil.ReadAll(rvReq.Body)
-		if err != nil {
-			return err
-		}
+	deployment, ok, err := client.resolveEnvironment(ctx, owner, slug, environment)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// The environment is gone and its variables with it.
+		d.SetId("")
+		return nil
+	}
 
-		err = json.Unmarshal(body, &prv)
+	rv, err := client.DeploymentVariables.Get(ctx, owner, slug, deployment, d.Get("uuid").(string))
+	if api.IsNotFound(err) {
+		gone, err := client.environmentGone(ctx, owner, slug, deployment)
 		if err != nil {
 			return err
 		}
-
-		if prv.Size < 1 {
-			d.SetId("")
-			return nil
+		if gone {
+			log.Printf("[WARN] Deployment environment %s of %s/%s was deleted, removing variable %s from the state", deployment, owner, slug, d.Id())
 		}
 
-		var uuid = d.Get("uuid").(string)
-		for _, rv := range prv.Values {
-			if rv.UUID == uuid {
-				d.SetId(rv.UUID)
-				d.Set("key", rv.Key)
-				d.Set("value", rv.Value)
-				d.Set("secured", rv.Secured)
-				return nil
-			}
-		}
 		d.SetId("")
+		return nil
 	}
 
-	if rvReq.StatusCode == 404 {
-		d.SetId("")
-		return nil
+	if err != nil {
+		return err
+	}
+
+	d.SetId(rv.UUID)
+	d.Set("key", rv.Key)
+	d.Set("secured", rv.Secured)
+	d.Set("repository", repository)
+	d.Set("environment", environment)
+	d.Set("environment_uuid", deployment)
+	d.Set("deployment", fmt.Sprintf("%s:%s", repository, deployment))
+
+	name, err := client.environmentName(ctx, owner, slug, deployment)
+	if err != nil {
+		return err
 	}
+	d.Set("environment_name", name)
 
+	// Bitbucket never returns the value of a secured variable, keep the one that was sent.
+	if !rv.Secured {
+		d.Set("value", rv.Value)
+	}
 	return nil
 }
 
 func resourceDeploymentVariableUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	rvcr := newDeploymentVariableFromResource(d)
-	bytedata, err := json.Marshal(rvcr)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	repository, environment := deploymentVariableTarget(d)
+	owner, slug := client.splitRepository(repository)
+
+	deployment, ok, err := client.resolveEnvironment(ctx, owner, slug, environment)
 	if err != nil {
 		return err
 	}
+	if !ok {
+		return fmt.Errorf("%s/%s has no deployment environment named %q", owner, slug, environment)
+	}
+
+	uuid := d.Get("uuid").(string)
+	if d.HasChange("key") {
+		old, new := d.GetChange("key")
+		if err := client.checkDuplicateKey(ctx, owner, slug, deployment, new.(string), uuid); err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Renaming deployment variable %s from %s to %s", uuid, old, new)
+	}
 
-	repository, deployment := parseDeploymentId(d.Get("deployment").(string))
-	req, err := client.Put(fmt.Sprintf("2.0/repositories/%s/deployments_config/environments/%s/variables/%s",
-		repository,
-		deployment,
-		d.Get("uuid").(string),
-	), bytes.NewBuffer(bytedata))
+	_, err = client.DeploymentVariables.Update(ctx, owner, slug, deployment, uuid, newDeploymentVariableFromResource(d))
 	if err != nil {
-		return err
+		return client.explainMissingEnvironment(ctx, owner, slug, deployment, err)
 	}
 
-	if req.StatusCode != 200 {
-		return nil
+	if d.HasChange("key") {
+		// A rename keeps the uuid, make sure bitbucket really serves the variable under its new key
+		// before reading it back.
+		key := d.Get("key").(string)
+		err = client.waitUntilVisible(ctx, fmt.Sprintf("deployment variable %s renamed to %s", uuid, key), func() (bool, error) {
+			// List, unlike Get, never answers from what was listed before
+			variables, err := client.DeploymentVariables.List(ctx, owner, slug, deployment)
+			if err != nil {
+				return false, err
+			}
+			for _, variable := range variables {
+				if variable.UUID == uuid {
+					return variable.Key == key, nil
+				}
+			}
+			return false, nil
+		})
+		if err != nil {
+			return err
+		}
 	}
+
 	return resourceDeploymentVariableRead(d, m)
 }
 
+// resourceDeploymentVariableImport adopts a variable by an id like
+// `workspace/repo_slug/environment_uuid/variable_uuid`, the variable can also be given by its key.
+func resourceDeploymentVariableImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		return nil, fmt.Errorf("Expected an import id like workspace/repo_slug/environment_uuid/variable_uuid or workspace/repo_slug/environment_uuid/key, got %q", d.Id())
+	}
+
+	owner, slug, deployment, variable := parts[0], parts[1], withBraces(parts[2]), parts[3]
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	variables, err := client.DeploymentVariables.List(ctx, owner, slug, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rv := range variables {
+		if rv.UUID == withBraces(variable) || rv.Key == variable {
+			d.Set("repository", fmt.Sprintf("%s/%s", owner, slug))
+			d.Set("environment", deployment)
+			d.Set("uuid", rv.UUID)
+			d.SetId(rv.UUID)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Deployment %s of %s/%s has no variable with the uuid or key %q", deployment, owner, slug, variable)
+}
+
+// withBraces wraps a uuid in the braces bitbucket puts around them, the UI shows them without
+func withBraces(uuid string) string {
+	if strings.HasPrefix(uuid, "{") {
+		return uuid
+	}
+	return "{" + uuid + "}"
+}
+
 func resourceDeploymentVariableDelete(d *schema.ResourceData, m interface{}) error {
-	repository, deployment := parseDeploymentId(d.Get("deployment").(string))
+	repository, environment := deploymentVariableTarget(d)
 	client := m.(*Client)
-	_, err := client.Delete(fmt.Sprintf(fmt.Sprintf("2.0/repositories/%s/deployments_config/environments/%s/variables/%s",
-		repository,
-		deployment,
-		d.Get("uuid").(string),
-	)))
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	owner, slug := client.splitRepository(repository)
+
+	deployment, ok, err := client.resolveEnvironment(ctx, owner, slug, environment)
+	if err != nil || !ok {
+		// Without the environment the variable is gone already.
+		return err
+	}
+
+	err = client.DeploymentVariables.Delete(ctx, owner, slug, deployment, d.Get("uuid").(string))
+	if api.IsNotFound(err) {
+		// Deleted already, maybe together with its environment.
+		return nil
+	}
 	return err
 }
@@ -0,0 +1,45 @@
+package bitbucket
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceDeploymentVariableV0 is the schema before the target of a variable was split into repository
+// and environment, when it was only set as `repository:environment` in deployment.
+func resourceDeploymentVariableV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"value": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"secured": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"deployment": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+// resourceDeploymentVariableStateUpgradeV0 fills repository and environment from deployment
+func resourceDeploymentVariableStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if deployment, ok := rawState["deployment"].(string); ok && deployment != "" {
+		rawState["repository"], rawState["environment"] = parseDeploymentId(deployment)
+	}
+
+	return rawState, nil
+}
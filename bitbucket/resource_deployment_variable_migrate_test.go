@@ -0,0 +1,29 @@
+package bitbucket
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourceDeploymentVariableStateUpgradeV0(t *testing.T) {
+	v0 := map[string]interface{}{
+		"key":        "COUNTRY",
+		"deployment": "gob/illusions:{staging}",
+	}
+
+	expected := map[string]interface{}{
+		"key":         "COUNTRY",
+		"deployment":  "gob/illusions:{staging}",
+		"repository":  "gob/illusions",
+		"environment": "{staging}",
+	}
+
+	actual, err := resourceDeploymentVariableStateUpgradeV0(v0, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
@@ -1,11 +1,18 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+
+	"terraform-provider-bitbucket/bitbucket/api"
 )
 
 func TestAccBitbucketDeploymentVariable_basic(t *testing.T) {
@@ -24,11 +31,14 @@ func TestAccBitbucketDeploymentVariable_basic(t *testing.T) {
 		resource "bitbucket_deployment_variable" "testvar" {
 			key = "test"
 			value = "test"
-			deployment = bitbucket_deployment.test_deploy.id
+			repository = bitbucket_repository.test_repo.id
+			environment = bitbucket_deployment.test_deploy.uuid
 			secured = false
 		  }
 	`, testUser)
 
+	var uuid string
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -38,12 +48,55 @@ func TestAccBitbucketDeploymentVariable_basic(t *testing.T) {
 				Config: testAccBitbucketDeploymentVariableConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckBitbucketDeploymentVariableExists("bitbucket_deployment_variable.testvar", "test", "test"),
+					testAccCheckBitbucketDeploymentVariableUUID("bitbucket_deployment_variable.testvar", &uuid),
 				),
 			},
+			{
+				Config: strings.Replace(testAccBitbucketDeploymentVariableConfig, `key = "test"`, `key = "renamed"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketDeploymentVariableExists("bitbucket_deployment_variable.testvar", "renamed", "test"),
+					testAccCheckBitbucketDeploymentVariableUUID("bitbucket_deployment_variable.testvar", &uuid),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_deployment_variable.testvar",
+				ImportState:       true,
+				ImportStateIdFunc: testAccBitbucketDeploymentVariableImportID("bitbucket_deployment_variable.testvar"),
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
 
+// testAccCheckBitbucketDeploymentVariableUUID remembers the uuid of the variable in uuid and fails when
+// it changed since it was remembered, a variable that was updated in place keeps its uuid.
+func testAccCheckBitbucketDeploymentVariableUUID(n string, uuid *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found %s", n)
+		}
+
+		if *uuid != "" && rs.Primary.Attributes["uuid"] != *uuid {
+			return fmt.Errorf("Expected the variable to keep the uuid %s, got %s", *uuid, rs.Primary.Attributes["uuid"])
+		}
+		*uuid = rs.Primary.Attributes["uuid"]
+
+		return nil
+	}
+}
+
+func testAccBitbucketDeploymentVariableImportID(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found %s", n)
+		}
+
+		return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["repository"], rs.Primary.Attributes["environment"], rs.Primary.Attributes["key"]), nil
+	}
+}
+
 func testAccCheckBitbucketDeploymentVariableDestroy(s *terraform.State) error {
 	_, ok := s.RootModule().Resources["bitbucket_deployment_variable.testvar"]
 	if !ok {
@@ -71,3 +124,178 @@ func testAccCheckBitbucketDeploymentVariableExists(n, key, value string) resourc
 		return nil
 	}
 }
+
+func TestClientResolveEnvironment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/repositories/gob/illusions/environments/" {
+			t.Fatalf("unexpected request for %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"values": [{"name": "Production", "uuid": "{production}"}]}`)
+	}))
+	defer server.Close()
+
+	client := &Client{Client: api.NewClient(server.Client())}
+	client.BaseURL = server.URL
+
+	cases := []struct {
+		environment string
+		uuid        string
+		ok          bool
+	}{
+		{"7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e", "{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}", true},
+		{"{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}", "{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}", true},
+		{"production", "{production}", true},
+		{"staging", "", false},
+	}
+
+	for _, tc := range cases {
+		uuid, ok, err := client.resolveEnvironment(context.Background(), "gob", "illusions", tc.environment)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if uuid != tc.uuid || ok != tc.ok {
+			t.Fatalf("expected %s to resolve to %q (%t), got %q (%t)", tc.environment, tc.uuid, tc.ok, uuid, ok)
+		}
+	}
+}
+
+func TestClientExplainMissingEnvironment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2.0/repositories/gob/illusions/environments/{staging}":
+			fmt.Fprint(w, `{"name": "Staging", "uuid": "{staging}"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "Not found"}}`)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{Client: api.NewClient(server.Client())}
+	client.BaseURL = server.URL
+
+	_, err := client.DeploymentVariables.Create(context.Background(), "gob", "illusions", "{deleted}", &api.DeploymentVariable{Key: "COUNTRY"})
+	if err = client.explainMissingEnvironment(context.Background(), "gob", "illusions", "{deleted}", err); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("expected the error to say the environment is gone, got %v", err)
+	}
+
+	_, err = client.DeploymentVariables.Create(context.Background(), "gob", "illusions", "{staging}", &api.DeploymentVariable{Key: "COUNTRY"})
+	if err = client.explainMissingEnvironment(context.Background(), "gob", "illusions", "{staging}", err); !api.IsNotFound(err) || strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("expected the 404 of an existing environment to be kept, got %v", err)
+	}
+}
+
+func TestClientCheckDuplicateKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"values": [{"key": "COUNTRY", "uuid": "{country}"}]}`)
+	}))
+	defer server.Close()
+
+	client := &Client{Client: api.NewClient(server.Client())}
+	client.BaseURL = server.URL
+
+	cases := []struct {
+		key       string
+		uuid      string
+		duplicate bool
+	}{
+		{"COUNTRY", "", true},
+		{"country", "", true},
+		{"COUNTRY", "{country}", false},
+		{"CITY", "", false},
+	}
+
+	for _, tc := range cases {
+		err := client.checkDuplicateKey(context.Background(), "gob", "illusions", "{staging}", tc.key, tc.uuid)
+		if (err != nil) != tc.duplicate {
+			t.Fatalf("expected %s (%s) to be a duplicate: %t, got %v", tc.key, tc.uuid, tc.duplicate, err)
+		}
+	}
+}
+
+func TestSuppressSameEnvironment(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDeploymentVariable().Schema, map[string]interface{}{})
+	d.Set("environment_uuid", "{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}")
+	d.Set("environment_name", "Production")
+
+	cases := []struct {
+		old, new string
+		suppress bool
+	}{
+		{"Production", "production", true},
+		{"production", "7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e", true},
+		{"{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}", "production", true},
+		{"production", "staging", false},
+		{"{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}", "staging", false},
+		{"production", "{0b7e5d6c-1f2a-4c3b-9d8e-7f6a5b4c3d2e}", false},
+	}
+
+	for _, tc := range cases {
+		if suppress := suppressSameEnvironment("environment", tc.old, tc.new, d); suppress != tc.suppress {
+			t.Fatalf("expected switching from %s to %s to be suppressed: %t, got %t", tc.old, tc.new, tc.suppress, suppress)
+		}
+	}
+}
+
+func TestResourceDeploymentVariable_importThenPlan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2.0/repositories/gob/illusions/environments/":
+			fmt.Fprint(w, `{"values": [{"name": "Production", "uuid": "{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}"}]}`)
+		case "/2.0/repositories/gob/illusions/deployments_config/environments/{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}/variables":
+			fmt.Fprint(w, `{"values": [{"key": "COUNTRY", "value": "Ireland", "uuid": "{country}"}]}`)
+		default:
+			t.Fatalf("unexpected request for %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{Client: api.NewClient(server.Client())}
+	client.BaseURL = server.URL
+
+	resource := resourceDeploymentVariable()
+	d := resource.Data(nil)
+	d.SetId("gob/illusions/7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e/COUNTRY")
+
+	imported, err := resourceDeploymentVariableImport(d, client)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := resourceDeploymentVariableRead(imported[0], client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The config names the environment the importer set by its uuid
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"key":         "COUNTRY",
+		"value":       "Ireland",
+		"repository":  "gob/illusions",
+		"environment": "production",
+	})
+
+	diff, err := resource.Diff(imported[0].State(), config, client)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if diff != nil && (diff.RequiresNew() || diff.Attributes["environment"] != nil) {
+		t.Fatalf("expected naming the imported environment not to be a change, got %v", diff)
+	}
+}
+
+func TestValidateDeploymentId(t *testing.T) {
+	cases := map[string]bool{
+		"gob/illusions:{staging}": true,
+		"illusions:{staging}":     true,
+		"gob/illusions":           false,
+		"gob/illusions:":          false,
+		":{staging}":              false,
+	}
+
+	for id, valid := range cases {
+		if _, errors := validateDeploymentId(id, "deployment"); (len(errors) == 0) != valid {
+			t.Fatalf("expected %q to be valid: %t, got %v", id, valid, errors)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package bitbucket
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+// resourceDeploymentVariables manages every variable of an environment at once. It is authoritative,
+// variables of the environment that are not part of it are deleted.
+func resourceDeploymentVariables() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDeploymentVariablesCreate,
+		Read:   resourceDeploymentVariablesRead,
+		Update: resourceDeploymentVariablesUpdate,
+		Delete: resourceDeploymentVariablesDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDeploymentVariablesImport,
+		},
+		CustomizeDiff: resourceDeploymentVariablesCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"environment": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressSameEnvironment,
+			},
+			"environment_uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"environment_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"variable": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      hashDeploymentVariable,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateVariableKey,
+						},
+						"value": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+						"secured": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"force_update": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"uuids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func hashDeploymentVariable(v interface{}) int {
+	variable := v.(map[string]interface{})
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%s-", variable["key"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", variable["value"].(string)))
+	buf.WriteString(fmt.Sprintf("%t-", variable["secured"].(bool)))
+
+	return hashcode.String(buf.String())
+}
+
+// resourceDeploymentVariablesCustomizeDiff fails the plan when two variables have keys that only differ
+// by case, if at all.
+func resourceDeploymentVariablesCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	keys := make(map[string]string)
+
+	for _, v := range d.Get("variable").(*schema.Set).List() {
+		key := v.(map[string]interface{})["key"].(string)
+		if key == "" {
+			// Not known yet
+			continue
+		}
+
+		if other, ok := keys[strings.ToLower(key)]; ok {
+			return fmt.Errorf("The variables %s and %s have the same key, bitbucket would keep both and the last one written wins", other, key)
+		}
+		keys[strings.ToLower(key)] = key
+	}
+
+	return nil
+}
+
+// deploymentVariablesByKey turns the variable blocks into variables keyed by their key
+func deploymentVariablesByKey(set *schema.Set) map[string]*api.DeploymentVariable {
+	variables := make(map[string]*api.DeploymentVariable, set.Len())
+
+	for _, v := range set.List() {
+		variable := v.(map[string]interface{})
+		variables[variable["key"].(string)] = &api.DeploymentVariable{
+			Key:     variable["key"].(string),
+			Value:   variable["value"].(string),
+			Secured: variable["secured"].(bool),
+		}
+	}
+
+	return variables
+}
+
+func resourceDeploymentVariablesCreate(d *schema.ResourceData, m interface{}) error {
+	if err := applyDeploymentVariables(d, m, schema.TimeoutCreate); err != nil {
+		return err
+	}
+
+	return resourceDeploymentVariablesRead(d, m)
+}
+
+func resourceDeploymentVariablesUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := applyDeploymentVariables(d, m, schema.TimeoutUpdate); err != nil {
+		return err
+	}
+
+	return resourceDeploymentVariablesRead(d, m)
+}
+
+// applyDeploymentVariables makes the variables of the environment match the configured ones. It only
+// sends what differs, the value of a secured variable is compared to the one applied last since bitbucket
+// never returns it.
+func applyDeploymentVariables(d *schema.ResourceData, m interface{}, timeout string) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, timeout)
+	defer cancel()
+
+	repository := client.repositoryFor(d.Get("repository").(string))
+	owner, slug := client.splitRepository(repository)
+
+	environment, ok, err := client.resolveEnvironment(ctx, owner, slug, d.Get("environment").(string))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s/%s has no deployment environment named %q", owner, slug, d.Get("environment"))
+	}
+
+	existing, err := client.DeploymentVariables.List(ctx, owner, slug, environment)
+	if err != nil {
+		return err
+	}
+
+	// A new force_update sends the secured values again, bitbucket can't tell whether they drifted.
+	force := d.HasChange("force_update")
+
+	old, new := d.GetChange("variable")
+	applied := deploymentVariablesByKey(old.(*schema.Set))
+	desired := deploymentVariablesByKey(new.(*schema.Set))
+
+	current := make(map[string]api.DeploymentVariable, len(existing))
+	for _, variable := range existing {
+		current[variable.Key] = variable
+	}
+
+	for key, variable := range desired {
+		remote, exists := current[key]
+		switch {
+		case !exists:
+			log.Printf("[DEBUG] Creating deployment variable %s in %s", key, environment)
+			if _, err := client.DeploymentVariables.Create(ctx, owner, slug, environment, variable); err != nil {
+				return client.explainMissingEnvironment(ctx, owner, slug, environment, err)
+			}
+		case remote.Secured != variable.Secured,
+			!remote.Secured && remote.Value != variable.Value,
+			remote.Secured && (force || applied[key] == nil || applied[key].Value != variable.Value):
+			log.Printf("[DEBUG] Updating deployment variable %s in %s", key, environment)
+			if _, err := client.DeploymentVariables.Update(ctx, owner, slug, environment, remote.UUID, variable); err != nil {
+				return client.explainMissingEnvironment(ctx, owner, slug, environment, err)
+			}
+		}
+	}
+
+	for key, remote := range current {
+		if _, ok := desired[key]; !ok {
+			log.Printf("[DEBUG] Deleting deployment variable %s from %s, it is not configured", key, environment)
+			if err := client.DeploymentVariables.Delete(ctx, owner, slug, environment, remote.UUID); err != nil && !api.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", repository, environment))
+	return nil
+}
+
+func resourceDeploymentVariablesRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	repository, _ := parseDeploymentId(d.Id())
+	owner, slug := client.splitRepository(repository)
+
+	environment, ok, err := client.resolveEnvironment(ctx, owner, slug, d.Get("environment").(string))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	variables, err := client.DeploymentVariables.List(ctx, owner, slug, environment)
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(variables) == 0 && d.Get("variable").(*schema.Set).Len() > 0 {
+		gone, err := client.environmentGone(ctx, owner, slug, environment)
+		if err != nil {
+			return err
+		}
+		if gone {
+			log.Printf("[WARN] Deployment environment %s of %s/%s was deleted, removing its variables from the state", environment, owner, slug)
+			d.SetId("")
+			return nil
+		}
+	}
+
+	applied := deploymentVariablesByKey(d.Get("variable").(*schema.Set))
+
+	set := make([]interface{}, 0, len(variables))
+	uuids := make(map[string]interface{}, len(variables))
+	for _, variable := range variables {
+		value := variable.Value
+		// Bitbucket never returns the value of a secured variable, keep the one that was sent.
+		if previous, ok := applied[variable.Key]; ok && variable.Secured {
+			value = previous.Value
+		}
+
+		set = append(set, map[string]interface{}{
+			"key":     variable.Key,
+			"value":   value,
+			"secured": variable.Secured,
+		})
+		uuids[variable.Key] = variable.UUID
+	}
+
+	// The id holds the repository with its owner, a bare slug in the config is kept as it is
+	if d.Get("repository").(string) == "" {
+		d.Set("repository", repository)
+	}
+	d.Set("environment_uuid", environment)
+
+	name, err := client.environmentName(ctx, owner, slug, environment)
+	if err != nil {
+		return err
+	}
+	d.Set("environment_name", name)
+	d.Set("variable", schema.NewSet(hashDeploymentVariable, set))
+	d.Set("uuids", uuids)
+
+	return nil
+}
+
+func resourceDeploymentVariablesDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	repository, environment := parseDeploymentId(d.Id())
+	owner, slug := client.splitRepository(repository)
+
+	for key, uuid := range d.Get("uuids").(map[string]interface{}) {
+		log.Printf("[DEBUG] Deleting deployment variable %s from %s", key, environment)
+		if err := client.DeploymentVariables.Delete(ctx, owner, slug, environment, uuid.(string)); err != nil && !api.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceDeploymentVariablesImport adopts the variables of an environment by an id like
+// `owner/slug:environment_uuid`, the id of its bitbucket_deployment.
+func resourceDeploymentVariablesImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	repository, environment := parseDeploymentId(d.Id())
+	if repository == "" || environment == "" {
+		return nil, fmt.Errorf("Expected an import id like owner/slug:{environment-uuid}, got %q", d.Id())
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", repository, withBraces(environment)))
+	d.Set("repository", repository)
+	d.Set("environment", withBraces(environment))
+
+	return []*schema.ResourceData{d}, nil
+}
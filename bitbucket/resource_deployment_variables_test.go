@@ -0,0 +1,134 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func TestAccBitbucketDeploymentVariables_basic(t *testing.T) {
+
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketDeploymentVariablesConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-deployment-variables"
+		}
+		resource "bitbucket_deployment" "test_deploy" {
+			name = "testdeploy"
+			stage = "Test"
+			repository = bitbucket_repository.test_repo.id
+		}
+		resource "bitbucket_deployment_variables" "testvars" {
+			repository = bitbucket_repository.test_repo.id
+			environment = bitbucket_deployment.test_deploy.uuid
+
+			variable {
+				key = "first"
+				value = "one"
+			}
+
+			variable {
+				key = "second"
+				value = "two"
+				secured = true
+			}
+		}
+	`, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketDeploymentVariablesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketDeploymentVariablesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_deployment_variables.testvars", "variable.#", "2"),
+					testAccCheckBitbucketDeploymentVariablesKeys("bitbucket_deployment_variables.testvars", "first", "second"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketDeploymentVariablesConfig, `key = "first"`, `key = "third"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_deployment_variables.testvars", "variable.#", "2"),
+					testAccCheckBitbucketDeploymentVariablesKeys("bitbucket_deployment_variables.testvars", "second", "third"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketDeploymentVariablesDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_deployment_variables.testvars"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_deployment_variables.testvars")
+	}
+	return nil
+}
+
+// testAccCheckBitbucketDeploymentVariablesKeys checks the environment has exactly the variables keys
+func testAccCheckBitbucketDeploymentVariablesKeys(n string, keys ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found %s", n)
+		}
+
+		if rs.Primary.Attributes["uuids.%"] != fmt.Sprint(len(keys)) {
+			return fmt.Errorf("Expected %d variables, got %s", len(keys), rs.Primary.Attributes["uuids.%"])
+		}
+
+		for _, key := range keys {
+			if rs.Primary.Attributes["uuids."+key] == "" {
+				return fmt.Errorf("Expected a variable with the key %s", key)
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestResourceDeploymentVariablesRead_bareSlug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2.0/repositories/gob/illusions/environments/":
+			fmt.Fprint(w, `{"values": [{"name": "Staging", "uuid": "{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}"}]}`)
+		case "/2.0/repositories/gob/illusions/deployments_config/environments/{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}/variables":
+			fmt.Fprint(w, `{"values": [{"key": "COUNTRY", "value": "Ireland", "uuid": "{country}"}]}`)
+		default:
+			t.Fatalf("unexpected request for %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{Client: api.NewClient(server.Client())}
+	client.Workspace = "gob"
+	client.BaseURL = server.URL
+
+	d := schema.TestResourceDataRaw(t, resourceDeploymentVariables().Schema, map[string]interface{}{
+		"repository":  "illusions",
+		"environment": "{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}",
+	})
+	d.SetId("gob/illusions:{7c3c2e5a-5b0a-4a59-8b8d-2f0e9c1c6d3e}")
+
+	if err := resourceDeploymentVariablesRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if repository := d.Get("repository").(string); repository != "illusions" {
+		t.Fatalf("expected the configured slug to be kept, got %q", repository)
+	}
+	if uuid := d.Get("uuids").(map[string]interface{})["COUNTRY"]; uuid != "{country}" {
+		t.Fatalf("expected the variable to be read, got %v", d.Get("uuids"))
+	}
+}
@@ -0,0 +1,210 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGroupCreate,
+		Read:   resourceGroupRead,
+		Update: resourceGroupUpdate,
+		Delete: resourceGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"slug": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// permission and auto_add are left as they are when not set, bitbucket_group_default_privilege
+			// manages them on its own
+			"permission": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"read",
+					"write",
+					"admin",
+				},
+					false),
+			},
+			"auto_add": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"email_forwarding_disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Only managed when set, bitbucket_group_membership adds single members instead
+			"members": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Computed: true,
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+// updateGroupFromResource changes group to what is configured. permission and auto_add are only changed
+// when they are set or changed, the 1.0 API replaces the whole group so they are sent as they were
+// otherwise.
+func updateGroupFromResource(d *schema.ResourceData, group *api.WorkspaceGroup) {
+	group.Name = d.Get("name").(string)
+	group.EmailForwardingDisabled = d.Get("email_forwarding_disabled").(bool)
+	// Members are changed through their own endpoints
+	group.Members = nil
+
+	if d.HasChange("permission") {
+		group.Permission = nil
+		if permission := d.Get("permission").(string); permission != "" {
+			group.Permission = &permission
+		}
+	}
+
+	if autoAdd, ok := d.GetOkExists("auto_add"); ok && d.HasChange("auto_add") {
+		group.AutoAdd = autoAdd.(bool)
+	}
+}
+
+func resourceGroupCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	workspace, err := client.workspaceIn(d, "workspace")
+	if err != nil {
+		return err
+	}
+
+	group, err := client.Groups.Create(ctx, workspace, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", workspace, group.Slug))
+
+	// The 1.0 API only takes the name on create, the other settings are set afterwards
+	updateGroupFromResource(d, group)
+	_, err = client.Groups.Update(ctx, workspace, group.Slug, group)
+	if err != nil {
+		return err
+	}
+
+	if members, ok := d.GetOk("members"); ok {
+		if err := applyGroupMembers(ctx, client, workspace, group.Slug, members.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	return resourceGroupRead(d, m)
+}
+
+func resourceGroupRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `workspace/slug`")
+	}
+	d.Set("workspace", idparts[0])
+	d.Set("slug", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	group, err := client.Groups.Get(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", group.Name)
+	permission := ""
+	if group.Permission != nil {
+		permission = *group.Permission
+	}
+	d.Set("permission", permission)
+	d.Set("auto_add", group.AutoAdd)
+	d.Set("email_forwarding_disabled", group.EmailForwardingDisabled)
+
+	members := make([]string, 0, len(group.Members))
+	for _, member := range group.Members {
+		members = append(members, member.UUID)
+	}
+	d.Set("members", members)
+
+	return nil
+}
+
+func resourceGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	group, err := client.Groups.Get(ctx, d.Get("workspace").(string), d.Get("slug").(string))
+	if err != nil {
+		return err
+	}
+
+	// A renamed group keeps its slug
+	updateGroupFromResource(d, group)
+	_, err = client.Groups.Update(ctx, d.Get("workspace").(string), d.Get("slug").(string), group)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("members") {
+		err := applyGroupMembers(ctx, client, d.Get("workspace").(string), d.Get("slug").(string), d.Get("members").(*schema.Set))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceGroupRead(d, m)
+}
+
+func resourceGroupDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.Groups.Delete(ctx, d.Get("workspace").(string), d.Get("slug").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
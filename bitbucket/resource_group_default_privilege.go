@@ -0,0 +1,152 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+// resourceGroupDefaultPrivilege manages what a group of a workspace gets on the repositories of the
+// workspace and has it added to the ones created later, without managing the group itself.
+func resourceGroupDefaultPrivilege() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGroupDefaultPrivilegeCreate,
+		Read:   resourceGroupDefaultPrivilegeRead,
+		Update: resourceGroupDefaultPrivilegeUpdate,
+		Delete: resourceGroupDefaultPrivilegeDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"read",
+					"write",
+					"admin",
+				},
+					false),
+			},
+		},
+	}
+}
+
+// updateGroupPermission changes the permission of a group, nil for none, and whether it is added to new
+// repositories with it. It keeps the other settings, the 1.0 API replaces the whole group.
+func (c *Client) updateGroupPermission(ctx context.Context, workspace, slug string, permission *string) error {
+	group, err := c.Groups.Get(ctx, workspace, slug)
+	if err != nil {
+		return err
+	}
+
+	group.Permission = permission
+	group.AutoAdd = permission != nil
+	// Members are changed through their own endpoints
+	group.Members = nil
+
+	_, err = c.Groups.Update(ctx, workspace, slug, group)
+	return err
+}
+
+func resourceGroupDefaultPrivilegeCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	workspace, err := client.workspaceIn(d, "workspace")
+	if err != nil {
+		return err
+	}
+
+	permission := d.Get("permission").(string)
+	if err := client.updateGroupPermission(ctx, workspace, d.Get("group").(string), &permission); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", workspace, d.Get("group").(string)))
+	return resourceGroupDefaultPrivilegeRead(d, m)
+}
+
+func resourceGroupDefaultPrivilegeRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `workspace/group`")
+	}
+	d.Set("workspace", idparts[0])
+	d.Set("group", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	group, err := client.Groups.Get(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// A group without a permission, or that is not added to new repositories, has no default privilege
+	if group.Permission == nil || !group.AutoAdd {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("permission", *group.Permission)
+
+	return nil
+}
+
+func resourceGroupDefaultPrivilegeUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	permission := d.Get("permission").(string)
+	if err := client.updateGroupPermission(ctx, d.Get("workspace").(string), d.Get("group").(string), &permission); err != nil {
+		return err
+	}
+
+	return resourceGroupDefaultPrivilegeRead(d, m)
+}
+
+// resourceGroupDefaultPrivilegeDelete takes the permission away and stops adding the group to new
+// repositories, the group itself is kept
+func resourceGroupDefaultPrivilegeDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.updateGroupPermission(ctx, d.Get("workspace").(string), d.Get("group").(string), nil)
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func TestAccBitbucketGroupDefaultPrivilege_basic(t *testing.T) {
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketGroupConfig := fmt.Sprintf(`
+		resource "bitbucket_group" "test_group" {
+			workspace = "%s"
+			name = "test-group-for-default-privilege-test"
+		}
+	`, testTeam)
+	testAccBitbucketGroupDefaultPrivilegeConfig := testAccBitbucketGroupConfig + `
+		resource "bitbucket_group_default_privilege" "test_privilege" {
+			workspace = bitbucket_group.test_group.workspace
+			group = bitbucket_group.test_group.slug
+			permission = "admin"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketGroupDefaultPrivilegeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketGroupDefaultPrivilegeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_group_default_privilege.test_privilege", "id", testTeam+"/test-group-for-default-privilege-test"),
+					resource.TestCheckResourceAttr("bitbucket_group_default_privilege.test_privilege", "permission", "admin"),
+					resource.TestCheckResourceAttr("bitbucket_group.test_group", "auto_add", "true"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketGroupDefaultPrivilegeConfig, `permission = "admin"`, `permission = "write"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_group_default_privilege.test_privilege", "permission", "write"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_group_default_privilege.test_privilege",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Destroying the privilege keeps the group
+				Config: testAccBitbucketGroupConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketGroupWithoutPermission("bitbucket_group.test_group"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckBitbucketGroupWithoutPermission checks the group exists, has no permission and is not
+// added to new repositories
+func testAccCheckBitbucketGroupWithoutPermission(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found %s", n)
+		}
+
+		group, err := client.Groups.Get(context.Background(), rs.Primary.Attributes["workspace"], rs.Primary.Attributes["slug"])
+		if err != nil {
+			return err
+		}
+
+		if group.Permission != nil {
+			return fmt.Errorf("Group still has the permission %s", *group.Permission)
+		}
+		if group.AutoAdd {
+			return fmt.Errorf("Group is still added to new repositories")
+		}
+		return nil
+	}
+}
+
+func testAccCheckBitbucketGroupDefaultPrivilegeDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	rs, ok := s.RootModule().Resources["bitbucket_group.test_group"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_group.test_group")
+	}
+
+	_, err := client.Groups.Get(context.Background(), rs.Primary.Attributes["workspace"], rs.Primary.Attributes["slug"])
+	if api.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("Group still exists")
+}
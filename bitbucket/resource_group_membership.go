@@ -0,0 +1,141 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGroupMembershipCreate,
+		Read:   resourceGroupMembershipRead,
+		Delete: resourceGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceGroupMembershipCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	workspace, err := client.workspaceIn(d, "workspace")
+	if err != nil {
+		return err
+	}
+
+	err = client.Groups.AddMember(ctx, workspace, d.Get("group").(string), d.Get("user").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", workspace, d.Get("group").(string), d.Get("user").(string)))
+	return resourceGroupMembershipRead(d, m)
+}
+
+func resourceGroupMembershipRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 3 {
+		return fmt.Errorf("Incorrect ID format, should match `workspace/group/user`")
+	}
+	d.Set("workspace", idparts[0])
+	d.Set("group", idparts[1])
+	d.Set("user", idparts[2])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	members, err := client.Groups.ListMembers(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if member.UUID == idparts[2] {
+			return nil
+		}
+	}
+
+	// Removed from the group outside of terraform
+	d.SetId("")
+	return nil
+}
+
+func resourceGroupMembershipDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.Groups.RemoveMember(ctx, d.Get("workspace").(string), d.Get("group").(string), d.Get("user").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// applyGroupMembers makes members the members of the group, the ones added outside of terraform are
+// removed as the group manages the whole list then.
+func applyGroupMembers(ctx context.Context, client *Client, workspace, slug string, members *schema.Set) error {
+	current, err := client.Groups.ListMembers(ctx, workspace, slug)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(current))
+	for _, member := range current {
+		existing[member.UUID] = true
+		if !members.Contains(member.UUID) {
+			if err := client.Groups.RemoveMember(ctx, workspace, slug, member.UUID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, user := range members.List() {
+		if existing[user.(string)] {
+			continue
+		}
+		if err := client.Groups.AddMember(ctx, workspace, slug, user.(string)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketGroupMembership_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketGroupMembershipConfig := fmt.Sprintf(`
+		data "bitbucket_user" "member" {
+			username = "%s"
+		}
+		resource "bitbucket_group" "test_group" {
+			workspace = "%s"
+			name = "test-group-for-membership-test"
+		}
+		resource "bitbucket_group_membership" "test_membership" {
+			workspace = "%s"
+			group = bitbucket_group.test_group.slug
+			user = data.bitbucket_user.member.uuid
+		}
+	`, testUser, testTeam, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketGroupMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketGroupMembershipConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("bitbucket_group_membership.test_membership", "user", "data.bitbucket_user.member", "uuid"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_group_membership.test_membership",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBitbucketGroup_members(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketGroupMembersConfig := fmt.Sprintf(`
+		data "bitbucket_user" "member" {
+			username = "%s"
+		}
+		resource "bitbucket_group" "test_group" {
+			workspace = "%s"
+			name = "test-group-for-members-test"
+			members = [
+				data.bitbucket_user.member.uuid,
+			]
+		}
+	`, testUser, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketGroupMembersConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_group.test_group", "members.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketGroupMembershipDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_group_membership.test_membership"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_group_membership.test_membership")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketGroup_basic(t *testing.T) {
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketGroupConfig := fmt.Sprintf(`
+		resource "bitbucket_group" "test_group" {
+			workspace = "%s"
+			name = "test-group-for-group-test"
+		}
+	`, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketGroupConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_group.test_group", "slug", "test-group-for-group-test"),
+					resource.TestCheckResourceAttr("bitbucket_group.test_group", "permission", ""),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketGroupConfig, `name = "test-group-for-group-test"`, `name = "test-group-for-group-test-renamed"
+			permission = "write"
+			auto_add = true`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_group.test_group", "slug", "test-group-for-group-test"),
+					resource.TestCheckResourceAttr("bitbucket_group.test_group", "permission", "write"),
+					resource.TestCheckResourceAttr("bitbucket_group.test_group", "auto_add", "true"),
+				),
+			},
+			{
+				// Without permission and auto_add in the config they are left as they are
+				Config: strings.Replace(testAccBitbucketGroupConfig, `name = "test-group-for-group-test"`, `name = "test-group-for-group-test-renamed"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_group.test_group", "permission", "write"),
+					resource.TestCheckResourceAttr("bitbucket_group.test_group", "auto_add", "true"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_group.test_group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketGroupDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	rs, ok := s.RootModule().Resources["bitbucket_group.test_group"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_group.test_group")
+	}
+
+	response, _ := client.Get(context.Background(), fmt.Sprintf("1.0/groups/%s/%s/", rs.Primary.Attributes["workspace"], rs.Primary.Attributes["slug"]))
+
+	if response.StatusCode != 404 {
+		return fmt.Errorf("Group still exists")
+	}
+
+	return nil
+}
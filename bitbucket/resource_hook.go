@@ -1,25 +1,10 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"log"
-	"net/url"
-
 	"github.com/hashicorp/terraform/helper/schema"
-)
 
-// Hook is the hook you want to add to a bitbucket repository
-type Hook struct {
-	UUID                 string   `json:"uuid,omitempty"`
-	URL                  string   `json:"url,omitempty"`
-	Description          string   `json:"description,omitempty"`
-	Active               bool     `json:"active,omitempty"`
-	SkipCertVerification bool     `json:"skip_cert_verification,omitempty"`
-	Events               []string `json:"events,omitempty"`
-}
+	"terraform-provider-bitbucket/bitbucket/api"
+)
 
 func resourceHook() *schema.Resource {
 	return &schema.Resource{
@@ -28,11 +13,18 @@ func resourceHook() *schema.Resource {
 		Update: resourceHookUpdate,
 		Delete: resourceHookDelete,
 		Exists: resourceHookExists,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"owner": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"repository": {
@@ -72,7 +64,7 @@ func resourceHook() *schema.Resource {
 	}
 }
 
-func createHook(d *schema.ResourceData) *Hook {
+func createHook(d *schema.ResourceData) *api.Hook {
 
 	events := make([]string, 0, len(d.Get("events").(*schema.Set).List()))
 
@@ -80,7 +72,7 @@ func createHook(d *schema.ResourceData) *Hook {
 		events = append(events, item.(string))
 	}
 
-	return &Hook{
+	return &api.Hook{
 		URL:                  d.Get("url").(string),
 		Description:          d.Get("description").(string),
 		Active:               d.Get("active").(bool),
@@ -91,96 +83,48 @@ func createHook(d *schema.ResourceData) *Hook {
 
 func resourceHookCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	hook := createHook(d)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
 
-	payload, err := json.Marshal(hook)
-	if err != nil {
+	if _, err := client.ownerFor(d); err != nil {
 		return err
 	}
 
-	hookReq, err := client.Post(fmt.Sprintf("2.0/repositories/%s/%s/hooks",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-	), bytes.NewBuffer(payload))
-
+	hook, err := client.Hooks.Create(ctx, d.Get("owner").(string), d.Get("repository").(string), createHook(d))
 	if err != nil {
 		return err
 	}
 
-	body, readerr := ioutil.ReadAll(hookReq.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	decodeerr := json.Unmarshal(body, &hook)
-	if decodeerr != nil {
-		return decodeerr
-	}
-
 	d.SetId(hook.UUID)
 
 	return resourceHookRead(d, m)
 }
 func resourceHookRead(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
 
-	hookReq, err := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-		url.PathEscape(d.Id()),
-	))
-
+	hook, err := client.Hooks.Get(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
 	if err != nil {
 		return err
 	}
 
-	log.Printf("ID: %s", url.PathEscape(d.Id()))
-
-	if hookReq.StatusCode == 200 {
-		var hook Hook
-
-		body, readerr := ioutil.ReadAll(hookReq.Body)
-		if readerr != nil {
-			return readerr
-		}
-
-		decodeerr := json.Unmarshal(body, &hook)
-		if decodeerr != nil {
-			return decodeerr
-		}
-
-		d.Set("uuid", hook.UUID)
-		d.Set("description", hook.Description)
-		d.Set("active", hook.Active)
-		d.Set("url", hook.URL)
-		d.Set("skip_cert_verification", hook.SkipCertVerification)
-
-		eventsList := make([]string, 0, len(hook.Events))
-
-		for _, event := range hook.Events {
-			eventsList = append(eventsList, event)
-		}
-
-		d.Set("events", eventsList)
-	}
+	d.Set("uuid", hook.UUID)
+	d.Set("description", hook.Description)
+	d.Set("active", hook.Active)
+	d.Set("url", hook.URL)
+	d.Set("skip_cert_verification", hook.SkipCertVerification)
+	d.Set("events", hook.Events)
 
 	return nil
 }
 
 func resourceHookUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	hook := createHook(d)
-	payload, err := json.Marshal(hook)
-	if err != nil {
-		return err
-	}
-
-	_, err = client.Put(fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-		url.PathEscape(d.Id()),
-	), bytes.NewBuffer(payload))
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
 
+	_, err := client.Hooks.Update(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id(), createHook(d))
 	if err != nil {
 		return err
 	}
@@ -190,25 +134,18 @@ func resourceHookUpdate(d *schema.ResourceData, m interface{}) error {
 
 func resourceHookExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
 	if _, okay := d.GetOk("uuid"); okay {
-		hookReq, err := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s",
-			d.Get("owner").(string),
-			d.Get("repository").(string),
-			url.PathEscape(d.Id()),
-		))
+		_, err := client.Hooks.Get(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
 
-		if err != nil {
-			log.Printf("[DEBUG] Req: %+v, Err: %+v", hookReq, err)
-			// If the hook was not found, we get the message "is not a valid hook".
-			// Return nil so we can show that the hook is gone.
-			if hookReq.StatusCode == 404 {
-				return false, nil
-			}
-
-			panic(err)
+		// If the hook was not found, we get the message "is not a valid hook".
+		// Return nil so we can show that the hook is gone.
+		if api.IsNotFound(err) {
+			return false, nil
 		}
 
-		if hookReq.StatusCode != 200 {
+		if err != nil {
 			return false, err
 		}
 
@@ -221,12 +158,8 @@ func resourceHookExists(d *schema.ResourceData, m interface{}) (bool, error) {
 
 func resourceHookDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	_, err := client.Delete(fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-		url.PathEscape(d.Id()),
-	))
-
-	return err
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
 
+	return client.Hooks.Delete(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
 }
@@ -1,6 +1,7 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
@@ -8,10 +9,12 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+
+	"terraform-provider-bitbucket/bitbucket/api"
 )
 
 func TestAccBitbucketHook_basic(t *testing.T) {
-	var hook Hook
+	var hook api.Hook
 
 	testUser := os.Getenv("BITBUCKET_USERNAME")
 	testAccBitbucketHookConfig := fmt.Sprintf(`
@@ -53,7 +56,7 @@ func testAccCheckBitbucketHookDestroy(s *terraform.State) error {
 		return fmt.Errorf("Not found %s", "bitbucket_hook.test_repo_hook")
 	}
 
-	response, err := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], url.PathEscape(rs.Primary.Attributes["uuid"])))
+	response, err := client.Get(context.Background(), fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], url.PathEscape(rs.Primary.Attributes["uuid"])))
 
 	if err == nil {
 		return fmt.Errorf("The resource was found should have errored")
@@ -66,7 +69,7 @@ func testAccCheckBitbucketHookDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketHookExists(n string, hook *Hook) resource.TestCheckFunc {
+func testAccCheckBitbucketHookExists(n string, hook *api.Hook) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
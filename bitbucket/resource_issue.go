@@ -0,0 +1,205 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceIssue() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIssueCreate,
+		Read:   resourceIssueRead,
+		Update: resourceIssueUpdate,
+		Delete: resourceIssueDelete,
+		Importer: &schema.ResourceImporter{
+			State: importNumberedInRepository,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"kind": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "bug",
+				ValidateFunc: validation.StringInSlice([]string{
+					"bug",
+					"enhancement",
+					"proposal",
+					"task",
+				}, false),
+			},
+			"priority": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "major",
+				ValidateFunc: validation.StringInSlice([]string{
+					"trivial",
+					"minor",
+					"major",
+					"critical",
+					"blocker",
+				}, false),
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "new",
+				ValidateFunc: validation.StringInSlice([]string{
+					"new",
+					"open",
+					"resolved",
+					"on hold",
+					"invalid",
+					"duplicate",
+					"wontfix",
+					"closed",
+				}, false),
+			},
+			"assignee": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressBraces,
+			},
+		},
+	}
+}
+
+// suppressBraces ignores the braces bitbucket puts around uuids
+func suppressBraces(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && new != "" && withBraces(old) == withBraces(new)
+}
+
+func newIssueFromResource(d *schema.ResourceData) *api.Issue {
+	issue := &api.Issue{
+		Title:    d.Get("title").(string),
+		Content:  &api.IssueContent{Raw: d.Get("content").(string)},
+		Kind:     d.Get("kind").(string),
+		Priority: d.Get("priority").(string),
+		State:    d.Get("state").(string),
+	}
+	if assignee := d.Get("assignee").(string); assignee != "" {
+		issue.Assignee = &api.User{UUID: withBraces(assignee)}
+	}
+	return issue
+}
+
+func resourceIssueCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	issue, err := client.Issues.Create(ctx, owner, d.Get("repository").(string), newIssueFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(issue.ID))
+
+	return resourceIssueRead(d, m)
+}
+
+func resourceIssueRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	issue, err := client.Issues.Get(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("title", issue.Title)
+	if issue.Content != nil {
+		d.Set("content", issue.Content.Raw)
+	}
+	d.Set("kind", issue.Kind)
+	d.Set("priority", issue.Priority)
+	d.Set("state", issue.State)
+	if issue.Assignee != nil {
+		d.Set("assignee", issue.Assignee.UUID)
+	} else {
+		d.Set("assignee", "")
+	}
+
+	return nil
+}
+
+func resourceIssueUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	_, err := client.Issues.Update(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id(), newIssueFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceIssueRead(d, m)
+}
+
+func resourceIssueDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.Issues.Delete(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// importNumberedInRepository adopts what bitbucket numbers within a repository, like an issue or pull
+// request, by an id like `owner/repository/id`. The id is the number bitbucket gave it.
+func importNumberedInRepository(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Expected an import id like owner/repository/id, got %q", d.Id())
+	}
+
+	d.Set("owner", parts[0])
+	d.Set("repository", parts[1])
+	d.SetId(parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
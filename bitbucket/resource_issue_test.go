@@ -0,0 +1,76 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketIssue_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketIssueConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-issue-test"
+			has_issues = true
+		}
+		resource "bitbucket_issue" "test_issue" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			title = "Set up your development environment"
+			content = "Clone the repository and run the tests."
+			kind = "task"
+		}
+	`, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketIssueDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketIssueConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_issue.test_issue", "state", "new"),
+					resource.TestCheckResourceAttr("bitbucket_issue.test_issue", "priority", "major"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketIssueConfig, `kind = "task"`, `kind = "task"
+			state = "resolved"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_issue.test_issue", "state", "resolved"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_issue.test_issue",
+				ImportState:       true,
+				ImportStateIdFunc: testAccBitbucketNumberedImportID("bitbucket_issue.test_issue"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccBitbucketNumberedImportID(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found %s", n)
+		}
+
+		return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], rs.Primary.ID), nil
+	}
+}
+
+func testAccCheckBitbucketIssueDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_issue.test_issue"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_issue.test_issue")
+	}
+	return nil
+}
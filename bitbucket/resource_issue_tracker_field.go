@@ -0,0 +1,120 @@
+package bitbucket
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+// resourceIssueTrackerField manages the components, milestones or versions of an issue tracker, kind is
+// the collection of them, one of components, milestones or versions. All of them only have a name.
+func resourceIssueTrackerField(kind string) *schema.Resource {
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			return resourceIssueTrackerFieldCreate(kind, d, m)
+		},
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return resourceIssueTrackerFieldRead(kind, d, m)
+		},
+		Update: func(d *schema.ResourceData, m interface{}) error {
+			return resourceIssueTrackerFieldUpdate(kind, d, m)
+		},
+		Delete: func(d *schema.ResourceData, m interface{}) error {
+			return resourceIssueTrackerFieldDelete(kind, d, m)
+		},
+		Importer: &schema.ResourceImporter{
+			State: importNumberedInRepository,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceIssueTrackerFieldCreate(kind string, d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	field, err := client.Issues.CreateField(ctx, owner, d.Get("repository").(string), kind, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(field.ID))
+
+	return resourceIssueTrackerFieldRead(kind, d, m)
+}
+
+func resourceIssueTrackerFieldRead(kind string, d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	field, err := client.Issues.GetField(ctx, d.Get("owner").(string), d.Get("repository").(string), kind, d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", field.Name)
+
+	return nil
+}
+
+func resourceIssueTrackerFieldUpdate(kind string, d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	_, err := client.Issues.UpdateField(ctx, d.Get("owner").(string), d.Get("repository").(string), kind, d.Id(), d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceIssueTrackerFieldRead(kind, d, m)
+}
+
+func resourceIssueTrackerFieldDelete(kind string, d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.Issues.DeleteField(ctx, d.Get("owner").(string), d.Get("repository").(string), kind, d.Id())
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func TestAccBitbucketIssueTrackerField_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+
+	cases := []struct {
+		kind    string
+		name    string
+		renamed string
+	}{
+		{"component", "Tricks", "Illusions"},
+		{"milestone", "1.0", "1.0 Beta"},
+		{"version", "1.0.0", "1.0.1"},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.kind, func(t *testing.T) {
+			n := fmt.Sprintf("bitbucket_issue_%s.test_%s", c.kind, c.kind)
+			testAccBitbucketIssueTrackerFieldConfig := fmt.Sprintf(`
+				resource "bitbucket_repository" "test_repo" {
+					owner = "%s"
+					name = "test-repo-for-issue-%s-test"
+					has_issues = true
+				}
+				resource "bitbucket_issue_%s" "test_%s" {
+					owner = "%s"
+					repository = bitbucket_repository.test_repo.name
+					name = "%s"
+				}
+			`, testUser, c.kind, c.kind, c.kind, testUser, c.name)
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:     func() { testAccPreCheck(t) },
+				Providers:    testAccProviders,
+				CheckDestroy: testAccCheckBitbucketIssueTrackerFieldDestroy(n, c.kind+"s"),
+				Steps: []resource.TestStep{
+					{
+						Config: testAccBitbucketIssueTrackerFieldConfig,
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(n, "name", c.name),
+						),
+					},
+					{
+						Config: strings.Replace(testAccBitbucketIssueTrackerFieldConfig, fmt.Sprintf(`name = "%s"`, c.name), fmt.Sprintf(`name = "%s"`, c.renamed), 1),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(n, "name", c.renamed),
+						),
+					},
+					{
+						ResourceName:      n,
+						ImportState:       true,
+						ImportStateIdFunc: testAccBitbucketNumberedImportID(n),
+						ImportStateVerify: true,
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccCheckBitbucketIssueTrackerFieldDestroy(n, kind string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found %s", n)
+		}
+
+		_, err := client.Issues.GetField(context.Background(), rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], kind, rs.Primary.ID)
+		if api.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%s still exists", n)
+	}
+}
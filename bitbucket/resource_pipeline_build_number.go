@@ -0,0 +1,128 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourcePipelineBuildNumber() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePipelineBuildNumberCreate,
+		Read:   resourcePipelineBuildNumberRead,
+		Update: resourcePipelineBuildNumberUpdate,
+		Delete: resourcePipelineBuildNumberDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"next": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateFunc:     validation.IntAtLeast(1),
+				DiffSuppressFunc: suppressReachedBuildNumber,
+			},
+		},
+	}
+}
+
+// suppressReachedBuildNumber ignores the builds run since the number was set, the number only has to be
+// raised once bitbucket is below it.
+func suppressReachedBuildNumber(k, old, new string, d *schema.ResourceData) bool {
+	current, err := strconv.Atoi(old)
+	if err != nil || d.Id() == "" {
+		return false
+	}
+	wanted, err := strconv.Atoi(new)
+	if err != nil {
+		return false
+	}
+	return current >= wanted
+}
+
+func resourcePipelineBuildNumberCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	err = client.Repositories.UpdateBuildNumber(ctx, owner, d.Get("repository").(string), &api.BuildNumber{Next: d.Get("next").(int)})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", owner, d.Get("repository").(string)))
+	return resourcePipelineBuildNumberRead(d, m)
+}
+
+func resourcePipelineBuildNumberRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/repository`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("repository", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	number, err := client.Repositories.GetBuildNumber(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("next", number.Next)
+
+	return nil
+}
+
+func resourcePipelineBuildNumberUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	err := client.Repositories.UpdateBuildNumber(ctx, d.Get("owner").(string), d.Get("repository").(string), &api.BuildNumber{Next: d.Get("next").(int)})
+	if err != nil {
+		return err
+	}
+
+	return resourcePipelineBuildNumberRead(d, m)
+}
+
+// resourcePipelineBuildNumberDelete only forgets the number, bitbucket can't lower it again
+func resourcePipelineBuildNumberDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketPipelineBuildNumber_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketPipelineBuildNumberConfig := `
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-pipeline-build-number-test"
+			pipelines_enabled = true
+		}
+		resource "bitbucket_pipeline_build_number" "test_number" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			next = %d
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketPipelineBuildNumberDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccBitbucketPipelineBuildNumberConfig, testUser, testUser, 1000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipeline_build_number.test_number", "next", "1000"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccBitbucketPipelineBuildNumberConfig, testUser, testUser, 2000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipeline_build_number.test_number", "next", "2000"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketPipelineBuildNumberDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_pipeline_build_number.test_number"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_pipeline_build_number.test_number")
+	}
+	return nil
+}
+
+func TestSuppressReachedBuildNumber(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePipelineBuildNumber().Schema, map[string]interface{}{"repository": "illusions", "next": 100})
+	if suppressReachedBuildNumber("next", "", "100", d) {
+		t.Fatalf("expected a new build number to be set")
+	}
+
+	d.SetId("gob/illusions")
+	cases := []struct {
+		old, new string
+		suppress bool
+	}{
+		{"100", "100", true},
+		{"142", "100", true},
+		{"100", "200", false},
+	}
+
+	for _, c := range cases {
+		if got := suppressReachedBuildNumber("next", c.old, c.new, d); got != c.suppress {
+			t.Errorf("suppressReachedBuildNumber(%s, %s) = %t, expected %t", c.old, c.new, got, c.suppress)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package bitbucket
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourcePipelineCachePurge clears caches of the pipelines of a repository when it is created and again
+// whenever triggers change, it behaves like an action. Destroying it leaves the caches as they are.
+func resourcePipelineCachePurge() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePipelineCachePurgeCreate,
+		Read:   resourcePipelineCachePurgeRead,
+		Delete: resourcePipelineCachePurgeDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"names": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"purged": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourcePipelineCachePurgeCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+	repository := d.Get("repository").(string)
+
+	caches, err := client.Pipelines.ListCaches(ctx, owner, repository)
+	if err != nil {
+		return err
+	}
+
+	// No names purges every cache
+	names := d.Get("names").(*schema.Set)
+	var purged []string
+	for _, cache := range caches {
+		if names.Len() > 0 && !names.Contains(cache.Name) {
+			continue
+		}
+
+		log.Printf("[DEBUG] Purging pipeline cache %s of %s/%s", cache.Name, owner, repository)
+		if err := client.Pipelines.DeleteCache(ctx, owner, repository, cache.UUID); err != nil {
+			return err
+		}
+		purged = append(purged, cache.Name)
+	}
+
+	d.SetId(owner + "/" + repository)
+	d.Set("purged", purged)
+
+	return resourcePipelineCachePurgeRead(d, m)
+}
+
+// resourcePipelineCachePurgeRead keeps the state, the purged caches are filled again by the next pipelines
+func resourcePipelineCachePurgeRead(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+// resourcePipelineCachePurgeDelete only forgets the purge, caches are refilled by the pipelines using them
+func resourcePipelineCachePurgeDelete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("[DEBUG] Forgetting purge of pipeline caches of %s", d.Id())
+	return nil
+}
@@ -0,0 +1,52 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketPipelineCachePurge_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketPipelineCachePurgeConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-pipeline-cache-purge-test"
+			pipelines_enabled = true
+		}
+		resource "bitbucket_pipeline_cache_purge" "test_purge" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			names = ["node"]
+			triggers = {
+				image = "node:14"
+			}
+		}
+	`, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketPipelineCachePurgeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketPipelineCachePurgeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipeline_cache_purge.test_purge", "id", testUser+"/test-repo-for-pipeline-cache-purge-test"),
+					resource.TestCheckResourceAttr("bitbucket_pipeline_cache_purge.test_purge", "purged.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketPipelineCachePurgeDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_pipeline_cache_purge.test_purge"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_pipeline_cache_purge.test_purge")
+	}
+	return nil
+}
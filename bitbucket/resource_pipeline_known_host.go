@@ -0,0 +1,186 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourcePipelineKnownHost() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePipelineKnownHostCreate,
+		Read:   resourcePipelineKnownHostRead,
+		Update: resourcePipelineKnownHostUpdate,
+		Delete: resourcePipelineKnownHostDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePipelineKnownHostImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"hostname": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"public_key": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validatePublicKey,
+				DiffSuppressFunc: suppressPublicKeyComment,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"md5_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sha256_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// validatePublicKey wants a public key the way known_hosts has it, its type followed by the key
+func validatePublicKey(v interface{}, k string) (ws []string, errors []error) {
+	if len(strings.Fields(v.(string))) < 2 {
+		errors = append(errors, fmt.Errorf("%q must be a public key like `ssh-rsa AAAA...`, got %q", k, v.(string)))
+	}
+	return
+}
+
+func newPipelineKnownHostFromResource(d *schema.ResourceData) (*api.PipelineKnownHost, error) {
+	// The key may only be known at apply time, when validatePublicKey did not see it
+	fields := strings.Fields(d.Get("public_key").(string))
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("public_key must be a public key like `ssh-rsa AAAA...`, got %q", d.Get("public_key").(string))
+	}
+
+	return &api.PipelineKnownHost{
+		Hostname: d.Get("hostname").(string),
+		PublicKey: &api.PipelineKnownHostKey{
+			KeyType: fields[0],
+			Key:     fields[1],
+		},
+	}, nil
+}
+
+func resourcePipelineKnownHostCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	knownHost, err := newPipelineKnownHostFromResource(d)
+	if err != nil {
+		return err
+	}
+
+	host, err := client.PipelineSSH.CreateKnownHost(ctx, owner, d.Get("repository").(string), knownHost)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(host.UUID)
+
+	return resourcePipelineKnownHostRead(d, m)
+}
+
+func resourcePipelineKnownHostRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	host, err := client.PipelineSSH.GetKnownHost(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("uuid", host.UUID)
+	d.Set("hostname", host.Hostname)
+	if host.PublicKey != nil {
+		publicKey := host.PublicKey.KeyType + " " + host.PublicKey.Key
+		if publicKeyWithoutComment(publicKey) != publicKeyWithoutComment(d.Get("public_key").(string)) {
+			d.Set("public_key", publicKey)
+		}
+		d.Set("md5_fingerprint", host.PublicKey.MD5Fingerprint)
+		d.Set("sha256_fingerprint", host.PublicKey.SHA256Fingerprint)
+	}
+
+	return nil
+}
+
+func resourcePipelineKnownHostUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	knownHost, err := newPipelineKnownHostFromResource(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PipelineSSH.UpdateKnownHost(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id(), knownHost)
+	if err != nil {
+		return err
+	}
+
+	return resourcePipelineKnownHostRead(d, m)
+}
+
+func resourcePipelineKnownHostDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.PipelineSSH.DeleteKnownHost(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// resourcePipelineKnownHostImport adopts a known host by an id like `owner/repository/uuid`
+func resourcePipelineKnownHostImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Expected an import id like owner/repository/{known-host-uuid}, got %q", d.Id())
+	}
+
+	d.Set("owner", parts[0])
+	d.Set("repository", parts[1])
+	d.SetId(withBraces(parts[2]))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,82 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketPipelineKnownHost_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketPipelineKnownHostConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-pipeline-known-host-test"
+			pipelines_enabled = true
+		}
+		resource "bitbucket_pipeline_known_host" "test_host" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			hostname = "git.example.com"
+			public_key = "%s"
+		}
+	`, testUser, testUser, testAccPublicKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketPipelineKnownHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketPipelineKnownHostConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucket_pipeline_known_host.test_host", "sha256_fingerprint"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketPipelineKnownHostConfig, `"git.example.com"`, `"[git.example.com]:7999"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipeline_known_host.test_host", "hostname", "[git.example.com]:7999"),
+				),
+			},
+			{
+				ResourceName:        "bitbucket_pipeline_known_host.test_host",
+				ImportState:         true,
+				ImportStateIdPrefix: testUser + "/test-repo-for-pipeline-known-host-test/",
+				ImportStateVerify:   true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketPipelineKnownHostDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_pipeline_known_host.test_host"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_pipeline_known_host.test_host")
+	}
+	return nil
+}
+
+func TestNewPipelineKnownHostFromResource(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePipelineKnownHost().Schema, map[string]interface{}{
+		"hostname":   "example.com",
+		"public_key": "ssh-ed25519",
+	})
+	if _, err := newPipelineKnownHostFromResource(d); err == nil {
+		t.Fatalf("expected a public key without its key to be rejected")
+	}
+
+	d.Set("public_key", "ssh-ed25519 AAAA")
+	host, err := newPipelineKnownHostFromResource(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if host.PublicKey.KeyType != "ssh-ed25519" || host.PublicKey.Key != "AAAA" {
+		t.Fatalf("expected the key type and key to be split, got %+v", host.PublicKey)
+	}
+}
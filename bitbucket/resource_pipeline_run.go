@@ -0,0 +1,209 @@
+package bitbucket
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+// resourcePipelineRun starts a pipeline when it is created and again whenever triggers change, it behaves
+// like an action. Destroying it leaves the pipeline that ran as it is.
+func resourcePipelineRun() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePipelineRunCreate,
+		Read:   resourcePipelineRunRead,
+		Delete: resourcePipelineRunDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"branch": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"pipeline": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"variable": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateVariableKey,
+						},
+						"value": {
+							Type:      schema.TypeString,
+							Required:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+						"secured": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"wait_for_completion": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"build_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"result": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func newPipelineFromResource(d *schema.ResourceData) *api.Pipeline {
+	pipeline := &api.Pipeline{
+		Target: pipelineBranchTarget(d.Get("branch").(string), d.Get("pipeline").(string)),
+	}
+
+	for _, v := range d.Get("variable").([]interface{}) {
+		variable := v.(map[string]interface{})
+		pipeline.Variables = append(pipeline.Variables, api.PipelineVariable{
+			Key:     variable["key"].(string),
+			Value:   variable["value"].(string),
+			Secured: variable["secured"].(bool),
+		})
+	}
+
+	return pipeline
+}
+
+// pipelineResult is the result of a completed pipeline, empty until it completes
+func pipelineResult(pipeline *api.Pipeline) string {
+	if pipeline.State == nil || pipeline.State.Result == nil {
+		return ""
+	}
+	return pipeline.State.Result.Name
+}
+
+func resourcePipelineRunCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+	repository := d.Get("repository").(string)
+
+	pipeline, err := client.Pipelines.Run(ctx, owner, repository, newPipelineFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(pipeline.UUID)
+	d.Set("uuid", pipeline.UUID)
+	log.Printf("[DEBUG] Started pipeline #%d of %s/%s", pipeline.BuildNumber, owner, repository)
+
+	if d.Get("wait_for_completion").(bool) {
+		what := fmt.Sprintf("pipeline #%d of %s/%s", pipeline.BuildNumber, owner, repository)
+		_, err = waitForState(ctx, stateChange{
+			What:    what,
+			Pending: []string{"PENDING", "IN_PROGRESS"},
+			Target:  []string{"COMPLETED"},
+			MinWait: 5 * time.Second,
+			MaxWait: 30 * time.Second,
+			Refresh: func() (string, error) {
+				pipeline, err = client.Pipelines.Get(ctx, owner, repository, d.Id())
+				if err != nil || pipeline.State == nil {
+					return "", err
+				}
+				return pipeline.State.Name, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if result := pipelineResult(pipeline); result != "SUCCESSFUL" {
+			return fmt.Errorf("%s completed as %s, see its logs in bitbucket", what, result)
+		}
+	}
+
+	return resourcePipelineRunRead(d, m)
+}
+
+func resourcePipelineRunRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	pipeline, err := client.Pipelines.Get(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("uuid", pipeline.UUID)
+	d.Set("build_number", pipeline.BuildNumber)
+	if pipeline.State != nil {
+		d.Set("state", pipeline.State.Name)
+	}
+	d.Set("result", pipelineResult(pipeline))
+
+	return nil
+}
+
+// resourcePipelineRunDelete only forgets the pipeline, one that ran can't be undone
+func resourcePipelineRunDelete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("[DEBUG] Forgetting pipeline #%d of %s/%s", d.Get("build_number").(int), d.Get("owner"), d.Get("repository"))
+	return nil
+}
@@ -0,0 +1,67 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketPipelineRun_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketPipelineRunConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-pipeline-run-test"
+			pipelines_enabled = true
+		}
+		resource "bitbucket_commit_file" "test_pipelines" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			branch = "master"
+			file {
+				path = "bitbucket-pipelines.yml"
+				content = "pipelines:\n  custom:\n    bootstrap:\n      - step:\n          script:\n            - echo $STAGE\n"
+			}
+		}
+		resource "bitbucket_pipeline_run" "test_run" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			branch = bitbucket_commit_file.test_pipelines.branch
+			pipeline = "bootstrap"
+			wait_for_completion = true
+			variable {
+				key = "STAGE"
+				value = "test"
+			}
+			triggers = {
+				pipelines = bitbucket_commit_file.test_pipelines.hash
+			}
+		}
+	`, testUser, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketPipelineRunDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketPipelineRunConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipeline_run.test_run", "state", "COMPLETED"),
+					resource.TestCheckResourceAttr("bitbucket_pipeline_run.test_run", "result", "SUCCESSFUL"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketPipelineRunDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_pipeline_run.test_run"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_pipeline_run.test_run")
+	}
+	return nil
+}
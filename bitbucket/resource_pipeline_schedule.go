@@ -0,0 +1,191 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourcePipelineSchedule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePipelineScheduleCreate,
+		Read:   resourcePipelineScheduleRead,
+		Update: resourcePipelineScheduleUpdate,
+		Delete: resourcePipelineScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePipelineScheduleImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		// Bitbucket only lets enabled change, everything else recreates the schedule
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"branch": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"pipeline": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"cron_pattern": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCronPattern,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// validateCronPattern wants the seconds, minutes, hours, day of month, month, day of week and year bitbucket
+// schedules by, the year may be left out.
+func validateCronPattern(v interface{}, k string) (ws []string, errors []error) {
+	if fields := len(strings.Fields(v.(string))); fields != 6 && fields != 7 {
+		errors = append(errors, fmt.Errorf("%q must have 6 or 7 fields starting with the seconds, like `0 0 2 * * ? *`, got %q", k, v.(string)))
+	}
+	return
+}
+
+// pipelineBranchTarget targets the custom pipeline of a branch, or the one of the branch itself when no
+// custom pipeline is given
+func pipelineBranchTarget(branch, pipeline string) *api.PipelineTarget {
+	selector := &api.PipelineSelector{Type: "branches", Pattern: branch}
+	if pipeline != "" {
+		selector = &api.PipelineSelector{Type: "custom", Pattern: pipeline}
+	}
+
+	return &api.PipelineTarget{
+		Type:     "pipeline_ref_target",
+		RefType:  "branch",
+		RefName:  branch,
+		Selector: selector,
+	}
+}
+
+func newPipelineScheduleFromResource(d *schema.ResourceData) *api.PipelineSchedule {
+	return &api.PipelineSchedule{
+		Type:        "pipeline_schedule",
+		Enabled:     d.Get("enabled").(bool),
+		CronPattern: d.Get("cron_pattern").(string),
+		Target:      pipelineBranchTarget(d.Get("branch").(string), d.Get("pipeline").(string)),
+	}
+}
+
+func resourcePipelineScheduleCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	schedule, err := client.PipelineSchedules.Create(ctx, owner, d.Get("repository").(string), newPipelineScheduleFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(schedule.UUID)
+
+	return resourcePipelineScheduleRead(d, m)
+}
+
+func resourcePipelineScheduleRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	schedule, err := client.PipelineSchedules.Get(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("uuid", schedule.UUID)
+	d.Set("enabled", schedule.Enabled)
+	d.Set("cron_pattern", schedule.CronPattern)
+	if schedule.Target != nil {
+		d.Set("branch", schedule.Target.RefName)
+		pipeline := ""
+		if schedule.Target.Selector != nil && schedule.Target.Selector.Type == "custom" {
+			pipeline = schedule.Target.Selector.Pattern
+		}
+		d.Set("pipeline", pipeline)
+	}
+
+	return nil
+}
+
+func resourcePipelineScheduleUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	_, err := client.PipelineSchedules.Update(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id(), d.Get("enabled").(bool))
+	if err != nil {
+		return err
+	}
+
+	return resourcePipelineScheduleRead(d, m)
+}
+
+func resourcePipelineScheduleDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.PipelineSchedules.Delete(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// resourcePipelineScheduleImport adopts a schedule by an id like `owner/repository/uuid`
+func resourcePipelineScheduleImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Expected an import id like owner/repository/{schedule-uuid}, got %q", d.Id())
+	}
+
+	d.Set("owner", parts[0])
+	d.Set("repository", parts[1])
+	d.SetId(withBraces(parts[2]))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,79 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketPipelineSchedule_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketPipelineScheduleConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-pipeline-schedule-test"
+			pipelines_enabled = true
+		}
+		resource "bitbucket_pipeline_schedule" "test_schedule" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			branch = "master"
+			pipeline = "nightly"
+			cron_pattern = "0 0 2 * * ? *"
+		}
+	`, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketPipelineScheduleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketPipelineScheduleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipeline_schedule.test_schedule", "enabled", "true"),
+					resource.TestCheckResourceAttr("bitbucket_pipeline_schedule.test_schedule", "pipeline", "nightly"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketPipelineScheduleConfig, `cron_pattern = "0 0 2 * * ? *"`, `cron_pattern = "0 0 2 * * ? *"
+			enabled = false`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipeline_schedule.test_schedule", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:        "bitbucket_pipeline_schedule.test_schedule",
+				ImportState:         true,
+				ImportStateIdPrefix: testUser + "/test-repo-for-pipeline-schedule-test/",
+				ImportStateVerify:   true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketPipelineScheduleDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_pipeline_schedule.test_schedule"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_pipeline_schedule.test_schedule")
+	}
+	return nil
+}
+
+func TestValidateCronPattern(t *testing.T) {
+	for _, pattern := range []string{"0 0 2 * * ? *", "0 30 * * * ?"} {
+		if _, errors := validateCronPattern(pattern, "cron_pattern"); len(errors) != 0 {
+			t.Errorf("expected %q to be valid, got %v", pattern, errors)
+		}
+	}
+
+	for _, pattern := range []string{"0 2 * * *", ""} {
+		if _, errors := validateCronPattern(pattern, "cron_pattern"); len(errors) != 1 {
+			t.Errorf("expected %q to be rejected", pattern)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package bitbucket
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"golang.org/x/crypto/ssh"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourcePipelineSSHKey() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourcePipelineSSHKeyCreate,
+		Read:          resourcePipelineSSHKeyRead,
+		Update:        resourcePipelineSSHKeyUpdate,
+		Delete:        resourcePipelineSSHKeyDelete,
+		CustomizeDiff: resourcePipelineSSHKeyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"private_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validatePrivateKey,
+			},
+			"public_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// publicKeyOf derives the OpenSSH public key of a PEM encoded private key
+func publicKeyOf(privateKey string) (string, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))), nil
+}
+
+// validatePrivateKey only accepts unencrypted private keys, pipelines have no way to get the passphrase
+func validatePrivateKey(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := publicKeyOf(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an unencrypted private key: %s", k, err))
+	}
+	return
+}
+
+// resourcePipelineSSHKeyCustomizeDiff makes resources using the public key wait for the new one
+func resourcePipelineSSHKeyCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.HasChange("private_key") {
+		return d.SetNewComputed("public_key")
+	}
+	return nil
+}
+
+func newPipelineSSHKeyPairFromResource(d *schema.ResourceData) (*api.PipelineSSHKeyPair, error) {
+	publicKey, err := publicKeyOf(d.Get("private_key").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.PipelineSSHKeyPair{
+		PrivateKey: d.Get("private_key").(string),
+		PublicKey:  publicKey,
+	}, nil
+}
+
+func resourcePipelineSSHKeyCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	pair, err := newPipelineSSHKeyPairFromResource(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PipelineSSH.UpdateKeyPair(ctx, owner, d.Get("repository").(string), pair)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", owner, d.Get("repository").(string)))
+
+	return resourcePipelineSSHKeyRead(d, m)
+}
+
+func resourcePipelineSSHKeyRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/repository`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("repository", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	pair, err := client.PipelineSSH.GetKeyPair(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Bitbucket never serves the private key, a key pair replaced outside of terraform only shows in the
+	// public key. Forgetting the private key makes the next apply set it again.
+	if privateKey := d.Get("private_key").(string); privateKey != "" {
+		if publicKey, err := publicKeyOf(privateKey); err != nil || publicKeyWithoutComment(publicKey) != publicKeyWithoutComment(pair.PublicKey) {
+			log.Printf("[WARN] The pipelines ssh key pair of %s was replaced outside of terraform", d.Id())
+			d.Set("private_key", "")
+		}
+	}
+	d.Set("public_key", pair.PublicKey)
+
+	return nil
+}
+
+func resourcePipelineSSHKeyUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	pair, err := newPipelineSSHKeyPairFromResource(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PipelineSSH.UpdateKeyPair(ctx, d.Get("owner").(string), d.Get("repository").(string), pair)
+	if err != nil {
+		return err
+	}
+
+	return resourcePipelineSSHKeyRead(d, m)
+}
+
+func resourcePipelineSSHKeyDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.PipelineSSH.DeleteKeyPair(ctx, d.Get("owner").(string), d.Get("repository").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
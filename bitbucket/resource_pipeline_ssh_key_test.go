@@ -0,0 +1,99 @@
+package bitbucket
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// testPrivateKey generates a PEM encoded private key, pipelines want it unencrypted
+func testPrivateKey(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+}
+
+func TestAccBitbucketPipelineSSHKey_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketPipelineSSHKeyConfig := `
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-pipeline-ssh-key-test"
+			pipelines_enabled = true
+		}
+		resource "bitbucket_pipeline_ssh_key" "test_key" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			private_key = <<EOT
+%sEOT
+		}
+	`
+	first, second := testPrivateKey(t), testPrivateKey(t)
+	firstPublicKey, _ := publicKeyOf(first)
+	secondPublicKey, _ := publicKeyOf(second)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketPipelineSSHKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccBitbucketPipelineSSHKeyConfig, testUser, testUser, first),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipeline_ssh_key.test_key", "public_key", firstPublicKey),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccBitbucketPipelineSSHKeyConfig, testUser, testUser, second),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipeline_ssh_key.test_key", "public_key", secondPublicKey),
+				),
+			},
+			{
+				ResourceName:            "bitbucket_pipeline_ssh_key.test_key",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key"},
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketPipelineSSHKeyDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_pipeline_ssh_key.test_key"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_pipeline_ssh_key.test_key")
+	}
+	return nil
+}
+
+func TestValidatePrivateKey(t *testing.T) {
+	if _, errors := validatePrivateKey(testPrivateKey(t), "private_key"); len(errors) != 0 {
+		t.Fatalf("expected a private key to be valid, got %v", errors)
+	}
+
+	if _, errors := validatePrivateKey(testAccPublicKey, "private_key"); len(errors) != 1 {
+		t.Fatalf("expected a public key to be rejected")
+	}
+}
+
+func TestPublicKeyOf(t *testing.T) {
+	publicKey, err := publicKeyOf(testPrivateKey(t))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !strings.HasPrefix(publicKey, "ssh-rsa ") || strings.HasSuffix(publicKey, "\n") {
+		t.Fatalf("expected an ssh-rsa public key on one line, got %q", publicKey)
+	}
+}
@@ -0,0 +1,123 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourcePipelinesConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePipelinesConfigCreate,
+		Read:   resourcePipelinesConfigRead,
+		Update: resourcePipelinesConfigUpdate,
+		Delete: resourcePipelinesConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourcePipelinesConfigCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	pipelinesConfig := &api.PipelinesEnabled{Enabled: d.Get("enabled").(bool)}
+
+	err = client.Repositories.UpdatePipelinesConfig(ctx, owner, d.Get("repository").(string), pipelinesConfig)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", owner, d.Get("repository").(string)))
+	return resourcePipelinesConfigRead(d, m)
+}
+
+func resourcePipelinesConfigRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/repository`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("repository", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	pipelinesConfig, err := client.Repositories.GetPipelinesConfig(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("enabled", pipelinesConfig.Enabled)
+
+	return nil
+}
+
+func resourcePipelinesConfigUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	pipelinesConfig := &api.PipelinesEnabled{Enabled: d.Get("enabled").(bool)}
+
+	err := client.Repositories.UpdatePipelinesConfig(ctx, d.Get("owner").(string), d.Get("repository").(string), pipelinesConfig)
+	if err != nil {
+		return err
+	}
+
+	return resourcePipelinesConfigRead(d, m)
+}
+
+// resourcePipelinesConfigDelete turns pipelines off again, the way a repository starts
+func resourcePipelinesConfigDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.Repositories.UpdatePipelinesConfig(ctx, d.Get("owner").(string), d.Get("repository").(string), &api.PipelinesEnabled{Enabled: false})
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
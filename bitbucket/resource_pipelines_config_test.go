@@ -0,0 +1,59 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketPipelinesConfig_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketPipelinesConfigConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-pipelines-config-test"
+		}
+		resource "bitbucket_pipelines_config" "test_config" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+		}
+	`, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketPipelinesConfigDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketPipelinesConfigConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipelines_config.test_config", "enabled", "true"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketPipelinesConfigConfig, `repository = bitbucket_repository.test_repo.name`, `repository = bitbucket_repository.test_repo.name
+			enabled = false`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pipelines_config.test_config", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_pipelines_config.test_config",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketPipelinesConfigDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_pipelines_config.test_config"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_pipelines_config.test_config")
+	}
+	return nil
+}
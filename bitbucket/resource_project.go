@@ -1,24 +1,13 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
-	"strings"
-)
 
-// Project is the project data we need to send to create a project on the bitbucket api
-type Project struct {
-	Key         string `json:"key,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
-	Owner       string `json:"owner.username,omitempty"`
-	Description string `json:"description,omitempty"`
-	Name        string `json:"name,omitempty"`
-	UUID        string `json:"uuid,omitempty"`
-}
+	"terraform-provider-bitbucket/bitbucket/api"
+)
 
 func resourceProject() *schema.Resource {
 	return &schema.Resource{
@@ -26,6 +15,15 @@ func resourceProject() *schema.Resource {
 		Update: resourceProjectUpdate,
 		Read:   resourceProjectRead,
 		Delete: resourceProjectDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"key": {
@@ -43,85 +41,86 @@ func resourceProject() *schema.Resource {
 			},
 			"owner": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"avatar": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"avatar_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func newProjectFromResource(d *schema.ResourceData) *Project {
-	project := &Project{
+func newProjectFromResource(d *schema.ResourceData) *api.Project {
+	project := &api.Project{
 		Name:        d.Get("name").(string),
 		IsPrivate:   d.Get("is_private").(bool),
 		Description: d.Get("description").(string),
 		Key:         d.Get("key").(string),
 	}
 
+	// Only sent when set, bitbucket keeps the avatar a project has otherwise
+	if avatar := d.Get("avatar").(string); avatar != "" {
+		project.Links = &api.ProjectLinks{}
+		project.Links.Avatar.Href = avatar
+	}
+
 	return project
 }
 
 func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
 	project := newProjectFromResource(d)
 
-	var jsonbuffer []byte
-
-	jsonpayload := bytes.NewBuffer(jsonbuffer)
-	enc := json.NewEncoder(jsonpayload)
-	enc.Encode(project)
-
-	var projectKey string
-	projectKey = d.Get("key").(string)
-	if projectKey == "" {
-		projectKey = d.Get("key").(string)
+	// The key may be part of the change, the project is still found under the one in the id
+	owner, key := d.Get("owner").(string), d.Get("key").(string)
+	if idparts := strings.Split(d.Id(), "/"); len(idparts) == 2 {
+		owner, key = idparts[0], idparts[1]
 	}
 
-	_, err := client.Put(fmt.Sprintf("2.0/teams/%s/projects/%s",
-		d.Get("owner").(string),
-		projectKey,
-	), jsonpayload)
-
+	_, err := client.Projects.Update(ctx, owner, key, project)
 	if err != nil {
 		return err
 	}
 
+	d.SetId(fmt.Sprintf("%s/%s", owner, d.Get("key").(string)))
+
 	return resourceProjectRead(d, m)
 }
 
 func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	project := newProjectFromResource(d)
-
-	bytedata, err := json.Marshal(project)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
 
-	if err != nil {
+	if _, err := client.ownerFor(d); err != nil {
 		return err
 	}
 
-	var projectKey string
-	projectKey = d.Get("key").(string)
-	if projectKey == "" {
-		projectKey = d.Get("key").(string)
-	}
-
-	owner := d.Get("owner").(string)
-	if owner == "" {
-		return fmt.Errorf("owner must not be a empty string")
-	}
-
-	_, err = client.Post(fmt.Sprintf("2.0/teams/%s/projects/",
-		d.Get("owner").(string),
-	), bytes.NewBuffer(bytedata))
+	project := newProjectFromResource(d)
 
+	_, err := client.Projects.Create(ctx, d.Get("owner").(string), project)
 	if err != nil {
 		return err
 	}
 
-	d.SetId(string(fmt.Sprintf("%s/%s", d.Get("owner").(string), projectKey)))
+	d.SetId(string(fmt.Sprintf("%s/%s", d.Get("owner").(string), d.Get("key").(string))))
 
 	return resourceProjectRead(d, m)
 }
@@ -138,54 +137,35 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	var projectKey string
-	projectKey = d.Get("key").(string)
-	if projectKey == "" {
-		projectKey = d.Get("key").(string)
-	}
-
 	client := m.(*Client)
-	projectReq, _ := client.Get(fmt.Sprintf("2.0/teams/%s/projects/%s",
-		d.Get("owner").(string),
-		projectKey,
-	))
-
-	if projectReq.StatusCode == 200 {
-
-		var project Project
-
-		body, readerr := ioutil.ReadAll(projectReq.Body)
-		if readerr != nil {
-			return readerr
-		}
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+	project, err := client.Projects.Get(ctx, d.Get("owner").(string), d.Get("key").(string))
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
 
-		decodeerr := json.Unmarshal(body, &project)
-		if decodeerr != nil {
-			return decodeerr
-		}
+	if err != nil {
+		return err
+	}
 
-		d.Set("key", project.Key)
-		d.Set("is_private", project.IsPrivate)
-		d.Set("name", project.Name)
-		d.Set("description", project.Description)
+	d.Set("key", project.Key)
+	d.Set("is_private", project.IsPrivate)
+	d.Set("name", project.Name)
+	d.Set("description", project.Description)
+	d.Set("uuid", project.UUID)
+	if project.Links != nil {
+		d.Set("avatar_url", project.Links.Avatar.Href)
 	}
 
 	return nil
 }
 
 func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
-
-	var projectKey string
-	projectKey = d.Get("key").(string)
-	if projectKey == "" {
-		projectKey = d.Get("key").(string)
-	}
-
 	client := m.(*Client)
-	_, err := client.Delete(fmt.Sprintf("2.0/teams/%s/projects/%s",
-		d.Get("owner").(string),
-		projectKey,
-	))
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
 
-	return err
+	return client.Projects.Delete(ctx, d.Get("owner").(string), d.Get("key").(string))
 }
@@ -0,0 +1,113 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceProjectBranchingModel() *schema.Resource {
+	s := branchingModelSchema()
+	s["owner"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+	}
+	s["project"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	return &schema.Resource{
+		Create: resourceProjectBranchingModelCreate,
+		Read:   resourceProjectBranchingModelRead,
+		Update: resourceProjectBranchingModelUpdate,
+		Delete: resourceProjectBranchingModelDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: s,
+	}
+}
+
+func resourceProjectBranchingModelCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.BranchingModel.UpdateForProject(ctx, owner, d.Get("project").(string), branchingModelFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", owner, d.Get("project").(string)))
+	return resourceProjectBranchingModelRead(d, m)
+}
+
+func resourceProjectBranchingModelRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/project`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("project", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	model, err := client.BranchingModel.GetForProject(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	setBranchingModel(d, model)
+	return nil
+}
+
+func resourceProjectBranchingModelUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	_, err := client.BranchingModel.UpdateForProject(ctx, d.Get("owner").(string), d.Get("project").(string), branchingModelFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceProjectBranchingModelRead(d, m)
+}
+
+func resourceProjectBranchingModelDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	_, err := client.BranchingModel.UpdateForProject(ctx, d.Get("owner").(string), d.Get("project").(string), defaultBranchingModel())
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,62 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketProjectBranchingModel_basic(t *testing.T) {
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketProjectBranchingModelConfig := fmt.Sprintf(`
+		resource "bitbucket_project" "test_project" {
+			owner = "%s"
+			name = "test-project-for-branching-model-test"
+			key = "TESTMODEL"
+		}
+		resource "bitbucket_project_branching_model" "test_model" {
+			owner = "%s"
+			project = bitbucket_project.test_project.key
+
+			production {
+				enabled = false
+			}
+
+			branch_type {
+				kind = "release"
+				prefix = "rel/"
+			}
+		}
+	`, testTeam, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketProjectBranchingModelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketProjectBranchingModelConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_project_branching_model.test_model", "id", testTeam+"/TESTMODEL"),
+					resource.TestCheckResourceAttr("bitbucket_project_branching_model.test_model", "production.0.enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_project_branching_model.test_model",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketProjectBranchingModelDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_project_branching_model.test_model"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_project_branching_model.test_model")
+	}
+	return nil
+}
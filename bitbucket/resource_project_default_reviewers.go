@@ -0,0 +1,158 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceProjectDefaultReviewers() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProjectDefaultReviewersCreate,
+		Read:   resourceProjectDefaultReviewersRead,
+		Update: resourceProjectDefaultReviewersUpdate,
+		Delete: resourceProjectDefaultReviewersDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"reviewers": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceProjectDefaultReviewersCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	err = applyProjectDefaultReviewers(ctx, client, owner, d.Get("project").(string), d.Get("reviewers").(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", owner, d.Get("project").(string)))
+	return resourceProjectDefaultReviewersRead(d, m)
+}
+
+func resourceProjectDefaultReviewersRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/project`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("project", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	reviewers, err := client.DefaultReviewers.ListForProject(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var terraformReviewers []string
+
+	for _, reviewer := range reviewers {
+		terraformReviewers = append(terraformReviewers, reviewer.UUID)
+	}
+
+	d.Set("reviewers", terraformReviewers)
+
+	return nil
+}
+
+func resourceProjectDefaultReviewersUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	err := applyProjectDefaultReviewers(ctx, client, d.Get("owner").(string), d.Get("project").(string), d.Get("reviewers").(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	return resourceProjectDefaultReviewersRead(d, m)
+}
+
+func resourceProjectDefaultReviewersDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	for _, user := range d.Get("reviewers").(*schema.Set).List() {
+		err := client.DefaultReviewers.RemoveForProject(ctx, d.Get("owner").(string), d.Get("project").(string), user.(string))
+		if err != nil && !api.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyProjectDefaultReviewers makes reviewers the default reviewers of the project, the ones added
+// outside of terraform are removed as the resource manages the whole list.
+func applyProjectDefaultReviewers(ctx context.Context, client *Client, owner, key string, reviewers *schema.Set) error {
+	current, err := client.DefaultReviewers.ListForProject(ctx, owner, key)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(current))
+	for _, reviewer := range current {
+		existing[reviewer.UUID] = true
+		if !reviewers.Contains(reviewer.UUID) {
+			if err := client.DefaultReviewers.RemoveForProject(ctx, owner, key, reviewer.UUID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, user := range reviewers.List() {
+		if existing[user.(string)] {
+			continue
+		}
+		if err := client.DefaultReviewers.AddForProject(ctx, owner, key, user.(string)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketProjectDefaultReviewers_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketProjectDefaultReviewersConfig := fmt.Sprintf(`
+		data "bitbucket_user" "reviewer" {
+			username = "%s"
+		}
+		resource "bitbucket_project" "test_project" {
+			owner = "%s"
+			name = "test-project-default-reviewers"
+			key = "TESTREVIEW"
+		}
+
+		resource "bitbucket_project_default_reviewers" "test_reviewers" {
+			owner = "%s"
+			project = bitbucket_project.test_project.key
+			reviewers = [
+				data.bitbucket_user.reviewer.uuid,
+			]
+		}
+	`, testUser, testTeam, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketProjectDefaultReviewersDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketProjectDefaultReviewersConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_project_default_reviewers.test_reviewers", "id", testTeam+"/TESTREVIEW"),
+					resource.TestCheckResourceAttr("bitbucket_project_default_reviewers.test_reviewers", "reviewers.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_project_default_reviewers.test_reviewers",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketProjectDefaultReviewersDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_project_default_reviewers.test_reviewers"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_project_default_reviewers.test_reviewers")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+// projectPermissions are the permissions a group or user can have on a project, create-repo is write and
+// creating repositories in the project
+var projectPermissions = []string{
+	"read",
+	"write",
+	"create-repo",
+	"admin",
+}
+
+func resourceProjectGroupPermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProjectGroupPermissionCreate,
+		Read:   resourceProjectGroupPermissionRead,
+		Update: resourceProjectGroupPermissionUpdate,
+		Delete: resourceProjectGroupPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(projectPermissions, false),
+			},
+		},
+	}
+}
+
+func resourceProjectGroupPermissionCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	err = client.ProjectPermissions.UpdateGroup(ctx, owner, d.Get("project").(string), d.Get("group").(string), d.Get("permission").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", owner, d.Get("project").(string), d.Get("group").(string)))
+	return resourceProjectGroupPermissionRead(d, m)
+}
+
+func resourceProjectGroupPermissionRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 3 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/project/group`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("project", idparts[1])
+	d.Set("group", idparts[2])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	permission, err := client.ProjectPermissions.GetGroup(ctx, idparts[0], idparts[1], idparts[2])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("permission", permission.Permission)
+
+	return nil
+}
+
+func resourceProjectGroupPermissionUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	err := client.ProjectPermissions.UpdateGroup(ctx, d.Get("owner").(string), d.Get("project").(string), d.Get("group").(string), d.Get("permission").(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceProjectGroupPermissionRead(d, m)
+}
+
+func resourceProjectGroupPermissionDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.ProjectPermissions.DeleteGroup(ctx, d.Get("owner").(string), d.Get("project").(string), d.Get("group").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
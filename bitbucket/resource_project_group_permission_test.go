@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketProjectGroupPermission_basic(t *testing.T) {
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketProjectGroupPermissionConfig := fmt.Sprintf(`
+		resource "bitbucket_project" "test_project" {
+			owner = "%s"
+			name = "test-project-for-group-permission-test"
+			key = "TESTGROUPPERM"
+		}
+		resource "bitbucket_group" "test_group" {
+			workspace = "%s"
+			name = "test-group-for-project-permission-test"
+		}
+		resource "bitbucket_project_group_permission" "test_permission" {
+			owner = "%s"
+			project = bitbucket_project.test_project.key
+			group = bitbucket_group.test_group.slug
+			permission = "read"
+		}
+	`, testTeam, testTeam, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketProjectGroupPermissionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketProjectGroupPermissionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_project_group_permission.test_permission", "permission", "read"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketProjectGroupPermissionConfig, `permission = "read"`, `permission = "create-repo"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_project_group_permission.test_permission", "permission", "create-repo"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_project_group_permission.test_permission",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketProjectGroupPermissionDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_project_group_permission.test_permission"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_project_group_permission.test_permission")
+	}
+	return nil
+}
@@ -1,16 +1,20 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+
+	"terraform-provider-bitbucket/bitbucket/api"
 )
 
 func TestAccBitbucketProject_basic(t *testing.T) {
-	var project Project
+	var project api.Project
 
 	testTeam := os.Getenv("BITBUCKET_TEAM")
 	testAccBitbucketProjectConfig := fmt.Sprintf(`
@@ -32,6 +36,21 @@ func TestAccBitbucketProject_basic(t *testing.T) {
 					testAccCheckBitbucketProjectExists("bitbucket_project.test_project", &project),
 				),
 			},
+			{
+				Config: strings.Replace(strings.Replace(testAccBitbucketProjectConfig, `"TESTPROJ"`, `"TESTPROJRENAMED"`, 1), `name = "test-project-for-project-test"`, `name = "test-project-for-project-test"
+			description = "Illusions"
+			is_private = false`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_project.test_project", "id", testTeam+"/TESTPROJRENAMED"),
+					resource.TestCheckResourceAttr("bitbucket_project.test_project", "description", "Illusions"),
+					resource.TestCheckResourceAttr("bitbucket_project.test_project", "is_private", "false"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_project.test_project",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -43,7 +62,7 @@ func testAccCheckBitbucketProjectDestroy(s *terraform.State) error {
 		return fmt.Errorf("Not found %s", "bitbucket_project.test_project")
 	}
 
-	response, _ := client.Get(fmt.Sprintf("2.0/teams/%s/projects/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["name"]))
+	response, _ := client.Get(context.Background(), fmt.Sprintf("2.0/workspaces/%s/projects/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["key"]))
 
 	if response.StatusCode != 404 {
 		return fmt.Errorf("Project still exists")
@@ -52,7 +71,7 @@ func testAccCheckBitbucketProjectDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketProjectExists(n string, project *Project) resource.TestCheckFunc {
+func testAccCheckBitbucketProjectExists(n string, project *api.Project) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
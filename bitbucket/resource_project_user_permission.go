@@ -0,0 +1,127 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceProjectUserPermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProjectUserPermissionCreate,
+		Read:   resourceProjectUserPermissionRead,
+		Update: resourceProjectUserPermissionUpdate,
+		Delete: resourceProjectUserPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(projectPermissions, false),
+			},
+		},
+	}
+}
+
+func resourceProjectUserPermissionCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	err = client.ProjectPermissions.UpdateUser(ctx, owner, d.Get("project").(string), d.Get("user").(string), d.Get("permission").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", owner, d.Get("project").(string), d.Get("user").(string)))
+	return resourceProjectUserPermissionRead(d, m)
+}
+
+func resourceProjectUserPermissionRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 3 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/project/user`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("project", idparts[1])
+	d.Set("user", idparts[2])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	// Only the explicit permission is read, the one the user has through a group or the workspace would
+	// hide a permission taken away outside of terraform
+	permission, err := client.ProjectPermissions.GetUser(ctx, idparts[0], idparts[1], idparts[2])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("permission", permission.Permission)
+
+	return nil
+}
+
+func resourceProjectUserPermissionUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	err := client.ProjectPermissions.UpdateUser(ctx, d.Get("owner").(string), d.Get("project").(string), d.Get("user").(string), d.Get("permission").(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceProjectUserPermissionRead(d, m)
+}
+
+func resourceProjectUserPermissionDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.ProjectPermissions.DeleteUser(ctx, d.Get("owner").(string), d.Get("project").(string), d.Get("user").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
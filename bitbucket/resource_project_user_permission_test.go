@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketProjectUserPermission_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketProjectUserPermissionConfig := fmt.Sprintf(`
+		data "bitbucket_user" "collaborator" {
+			username = "%s"
+		}
+		resource "bitbucket_project" "test_project" {
+			owner = "%s"
+			name = "test-project-for-user-permission-test"
+			key = "TESTUSERPERM"
+		}
+		resource "bitbucket_project_user_permission" "test_permission" {
+			owner = "%s"
+			project = bitbucket_project.test_project.key
+			user = data.bitbucket_user.collaborator.uuid
+			permission = "read"
+		}
+	`, testUser, testTeam, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketProjectUserPermissionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketProjectUserPermissionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_project_user_permission.test_permission", "permission", "read"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketProjectUserPermissionConfig, `permission = "read"`, `permission = "create-repo"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_project_user_permission.test_permission", "permission", "create-repo"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_project_user_permission.test_permission",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketProjectUserPermissionDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_project_user_permission.test_permission"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_project_user_permission.test_permission")
+	}
+	return nil
+}
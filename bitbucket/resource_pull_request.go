@@ -0,0 +1,190 @@
+package bitbucket
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourcePullRequest() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePullRequestCreate,
+		Read:   resourcePullRequestRead,
+		Update: resourcePullRequestUpdate,
+		Delete: resourcePullRequestDelete,
+		Importer: &schema.ResourceImporter{
+			State: importNumberedInRepository,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"source_branch": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination_branch": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"reviewers": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"close_source_branch": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"decline_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func newPullRequestFromResource(d *schema.ResourceData) *api.PullRequest {
+	pr := &api.PullRequest{
+		Title:             d.Get("title").(string),
+		Description:       d.Get("description").(string),
+		Source:            &api.PullRequestBranch{Branch: &api.Ref{Name: d.Get("source_branch").(string)}},
+		Reviewers:         make([]api.Reviewer, 0),
+		CloseSourceBranch: d.Get("close_source_branch").(bool),
+	}
+	if destination := d.Get("destination_branch").(string); destination != "" {
+		pr.Destination = &api.PullRequestBranch{Branch: &api.Ref{Name: destination}}
+	}
+	for _, uuid := range d.Get("reviewers").(*schema.Set).List() {
+		pr.Reviewers = append(pr.Reviewers, api.Reviewer{UUID: uuid.(string)})
+	}
+	return pr
+}
+
+func resourcePullRequestCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.PullRequests.Create(ctx, owner, d.Get("repository").(string), newPullRequestFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(pr.ID))
+
+	return resourcePullRequestRead(d, m)
+}
+
+func resourcePullRequestRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	pr, err := client.PullRequests.Get(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("title", pr.Title)
+	d.Set("description", pr.Description)
+	d.Set("state", pr.State)
+	d.Set("close_source_branch", pr.CloseSourceBranch)
+	if pr.Source != nil && pr.Source.Branch != nil {
+		d.Set("source_branch", pr.Source.Branch.Name)
+	}
+	if pr.Destination != nil && pr.Destination.Branch != nil {
+		d.Set("destination_branch", pr.Destination.Branch.Name)
+	}
+
+	reviewers := make([]interface{}, 0, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		reviewers = append(reviewers, reviewer.UUID)
+	}
+	d.Set("reviewers", reviewers)
+
+	return nil
+}
+
+func resourcePullRequestUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	// decline_on_destroy only matters to terraform
+	if d.HasChange("decline_on_destroy") && !d.HasChange("destination_branch") && !d.HasChange("title") &&
+		!d.HasChange("description") && !d.HasChange("reviewers") && !d.HasChange("close_source_branch") {
+		return resourcePullRequestRead(d, m)
+	}
+
+	_, err := client.PullRequests.Update(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id(), newPullRequestFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	return resourcePullRequestRead(d, m)
+}
+
+// resourcePullRequestDelete declines the pull request unless it was merged or declined already, or
+// decline_on_destroy is turned off. Bitbucket can't delete pull requests.
+func resourcePullRequestDelete(d *schema.ResourceData, m interface{}) error {
+	if !d.Get("decline_on_destroy").(bool) || d.Get("state").(string) != "OPEN" {
+		log.Printf("[DEBUG] Leaving pull request %s of %s/%s as it is", d.Id(), d.Get("owner"), d.Get("repository"))
+		return nil
+	}
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.PullRequests.Decline(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
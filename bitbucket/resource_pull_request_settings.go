@@ -0,0 +1,163 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+// resourcePullRequestSettings manages how pull requests of a repository are merged as far as the Bitbucket
+// API allows it. The merge strategies can only be changed in the settings of the repository, they are
+// read from its main branch.
+func resourcePullRequestSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePullRequestSettingsCreate,
+		Read:   resourcePullRequestSettingsRead,
+		Update: resourcePullRequestSettingsUpdate,
+		Delete: resourcePullRequestSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"delete_source_branch": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"default_merge_strategy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"merge_strategies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourcePullRequestSettingsCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	err = client.updateBranchDeletion(ctx, owner, d.Get("repository").(string), d.Get("delete_source_branch").(bool))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", owner, d.Get("repository").(string)))
+	return resourcePullRequestSettingsRead(d, m)
+}
+
+func resourcePullRequestSettingsRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/repository`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("repository", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	model, err := client.BranchingModel.Get(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("delete_source_branch", model.DefaultBranchDeletion != nil && *model.DefaultBranchDeletion)
+
+	repo, err := client.Repositories.Get(ctx, idparts[0], idparts[1])
+	if err != nil {
+		return err
+	}
+
+	// An empty repository has no main branch yet
+	d.Set("default_merge_strategy", "")
+	d.Set("merge_strategies", nil)
+	if repo.MainBranch == nil || repo.MainBranch.Name == "" {
+		return nil
+	}
+
+	branch, err := client.Refs.GetBranch(ctx, idparts[0], idparts[1], repo.MainBranch.Name)
+	if api.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("default_merge_strategy", branch.DefaultMergeStrategy)
+	d.Set("merge_strategies", branch.MergeStrategies)
+
+	return nil
+}
+
+func resourcePullRequestSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	err := client.updateBranchDeletion(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Get("delete_source_branch").(bool))
+	if err != nil {
+		return err
+	}
+
+	return resourcePullRequestSettingsRead(d, m)
+}
+
+// resourcePullRequestSettingsDelete goes back to keeping source branches, which is what bitbucket starts with
+func resourcePullRequestSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.updateBranchDeletion(ctx, d.Get("owner").(string), d.Get("repository").(string), false)
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// updateBranchDeletion changes whether merging a pull request deletes its source branch by default, the
+// rest of the branching model is kept.
+func (c *Client) updateBranchDeletion(ctx context.Context, owner, repository string, deletion bool) error {
+	_, err := c.BranchingModel.Update(ctx, owner, repository, &api.BranchingModel{DefaultBranchDeletion: &deletion})
+	return err
+}
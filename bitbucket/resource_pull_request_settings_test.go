@@ -0,0 +1,69 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketPullRequestSettings_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketPullRequestSettingsConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-pull-request-settings-test"
+		}
+		resource "bitbucket_pull_request_settings" "test_settings" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			delete_source_branch = true
+		}
+	`, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketPullRequestSettingsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketPullRequestSettingsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pull_request_settings.test_settings", "id", testUser+"/test-repo-for-pull-request-settings-test"),
+					resource.TestCheckResourceAttr("bitbucket_pull_request_settings.test_settings", "delete_source_branch", "true"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketPullRequestSettingsConfig, "delete_source_branch = true", "delete_source_branch = false", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pull_request_settings.test_settings", "delete_source_branch", "false"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_pull_request_settings.test_settings",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketPullRequestSettingsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	rs, ok := s.RootModule().Resources["bitbucket_pull_request_settings.test_settings"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_pull_request_settings.test_settings")
+	}
+
+	response, _ := client.Get(context.Background(), fmt.Sprintf("2.0/repositories/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"]))
+
+	if response.StatusCode != 404 {
+		return fmt.Errorf("Repository still exists")
+	}
+
+	return nil
+}
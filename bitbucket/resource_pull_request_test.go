@@ -0,0 +1,73 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketPullRequest_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketPullRequestConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-pull-request-test"
+		}
+		resource "bitbucket_commit_file" "test_files" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			branch = "add-readme"
+			file {
+				path = "README.md"
+				content = "# Illusions"
+			}
+		}
+		resource "bitbucket_pull_request" "test_pull_request" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			source_branch = bitbucket_commit_file.test_files.branch
+			destination_branch = "master"
+			title = "Add a readme"
+		}
+	`, testUser, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketPullRequestDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketPullRequestConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pull_request.test_pull_request", "state", "OPEN"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketPullRequestConfig, `title = "Add a readme"`, `title = "Add a readme"
+			description = "Every repository needs one"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_pull_request.test_pull_request", "description", "Every repository needs one"),
+				),
+			},
+			{
+				ResourceName:            "bitbucket_pull_request.test_pull_request",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccBitbucketNumberedImportID("bitbucket_pull_request.test_pull_request"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"decline_on_destroy"},
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketPullRequestDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_pull_request.test_pull_request"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_pull_request.test_pull_request")
+	}
+	return nil
+}
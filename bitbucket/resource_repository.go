@@ -1,47 +1,15 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-
+	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
-)
-
-// CloneURL is the internal struct we use to represent urls
-type CloneURL struct {
-	Href string `json:"href,omitempty"`
-	Name string `json:"name,omitempty"`
-}
-
-// PipelinesEnabled is the struct we send to turn on or turn off pipelines for a repository
-type PipelinesEnabled struct {
-	Enabled bool `json:"enabled"`
-}
+	"github.com/hashicorp/terraform/helper/validation"
 
-// Repository is the struct we need to send off to the Bitbucket API to create a repository
-type Repository struct {
-	SCM         string `json:"scm,omitempty"`
-	HasWiki     bool   `json:"has_wiki,omitempty"`
-	HasIssues   bool   `json:"has_issues,omitempty"`
-	Website     string `json:"website,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
-	ForkPolicy  string `json:"fork_policy,omitempty"`
-	Language    string `json:"language,omitempty"`
-	Description string `json:"description,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Slug        string `json:"slug,omitempty"`
-	UUID        string `json:"uuid,omitempty"`
-	Project     struct {
-		Key string `json:"key,omitempty"`
-	} `json:"project,omitempty"`
-	Links struct {
-		Clone []CloneURL `json:"clone,omitempty"`
-	} `json:"links,omitempty"`
-}
+	"terraform-provider-bitbucket/bitbucket/api"
+)
 
 func resourceRepository() *schema.Resource {
 	return &schema.Resource{
@@ -52,12 +20,20 @@ func resourceRepository() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"scm": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "git",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "git",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"git", "hg"}, false),
 			},
 			"has_wiki": {
 				Type:     schema.TypeBool,
@@ -90,15 +66,21 @@ func resourceRepository() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			// Left as it is when not set, so bitbucket_pipelines_config can manage it instead
 			"pipelines_enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  false,
+				Computed: true,
 			},
 			"fork_policy": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "allow_forks",
+				ValidateFunc: validation.StringInSlice([]string{
+					"allow_forks",
+					"no_public_forks",
+					"no_forks",
+				}, false),
 			},
 			"language": {
 				Type:     schema.TypeString,
@@ -110,7 +92,9 @@ func resourceRepository() *schema.Resource {
 			},
 			"owner": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -125,8 +109,8 @@ func resourceRepository() *schema.Resource {
 	}
 }
 
-func newRepositoryFromResource(d *schema.ResourceData) *Repository {
-	repo := &Repository{
+func newRepositoryFromResource(d *schema.ResourceData) *api.Repository {
+	repo := &api.Repository{
 		Name:        d.Get("name").(string),
 		Slug:        d.Get("slug").(string),
 		Language:    d.Get("language").(string),
@@ -145,91 +129,69 @@ func newRepositoryFromResource(d *schema.ResourceData) *Repository {
 
 func resourceRepositoryUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	repository := newRepositoryFromResource(d)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
 
-	var jsonbuffer []byte
-
-	jsonpayload := bytes.NewBuffer(jsonbuffer)
-	enc := json.NewEncoder(jsonpayload)
-	enc.Encode(repository)
+	repository := newRepositoryFromResource(d)
 
-	var repoSlug string
-	repoSlug = d.Get("slug").(string)
-	if repoSlug == "" {
-		repoSlug = d.Get("name").(string)
+	// The slug may be part of the change, the repository is still found under the one in the id
+	owner, repoSlug := d.Get("owner").(string), d.Get("slug").(string)
+	if idparts := strings.Split(d.Id(), "/"); len(idparts) == 2 {
+		owner, repoSlug = idparts[0], idparts[1]
 	}
 
-	_, err := client.Put(fmt.Sprintf("2.0/repositories/%s/%s",
-		d.Get("owner").(string),
-		repoSlug,
-	), jsonpayload)
-
+	updated, err := client.Repositories.Update(ctx, owner, repoSlug, repository)
 	if err != nil {
 		return err
 	}
 
-	var pipelinesEnabled bool
-	pipelinesEnabled = d.Get("pipelines_enabled").(bool)
-	pipelinesConfig := &PipelinesEnabled{Enabled: pipelinesEnabled}
-
-	bytedata, err := json.Marshal(pipelinesConfig)
-
-	if err != nil {
-		return err
+	if updated.Slug != "" && updated.Slug != repoSlug {
+		log.Printf("[DEBUG] Repository %s/%s was renamed to %s/%s", owner, repoSlug, owner, updated.Slug)
+		repoSlug = updated.Slug
+		d.SetId(fmt.Sprintf("%s/%s", owner, repoSlug))
 	}
 
-	_, err = client.Put(fmt.Sprintf("2.0/repositories/%s/%s/pipelines_config",
-		d.Get("owner").(string),
-		repoSlug), bytes.NewBuffer(bytedata))
+	if d.HasChange("pipelines_enabled") {
+		pipelinesConfig := &api.PipelinesEnabled{Enabled: d.Get("pipelines_enabled").(bool)}
 
-	if err != nil {
-		return err
+		err = client.Repositories.UpdatePipelinesConfig(ctx, owner, repoSlug, pipelinesConfig)
+		if err != nil {
+			return err
+		}
 	}
 	return resourceRepositoryRead(d, m)
 }
 
 func resourceRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	repo := newRepositoryFromResource(d)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
 
-	bytedata, err := json.Marshal(repo)
-
-	if err != nil {
+	if _, err := client.ownerFor(d); err != nil {
 		return err
 	}
 
+	repo := newRepositoryFromResource(d)
+
 	var repoSlug string
 	repoSlug = d.Get("slug").(string)
 	if repoSlug == "" {
 		repoSlug = d.Get("name").(string)
 	}
 
-	_, err = client.Post(fmt.Sprintf("2.0/repositories/%s/%s",
-		d.Get("owner").(string),
-		repoSlug,
-	), bytes.NewBuffer(bytedata))
-
+	_, err := client.Repositories.Create(ctx, d.Get("owner").(string), repoSlug, repo)
 	if err != nil {
 		return err
 	}
 	d.SetId(string(fmt.Sprintf("%s/%s", d.Get("owner").(string), repoSlug)))
 
-	var pipelinesEnabled bool
-	pipelinesEnabled = d.Get("pipelines_enabled").(bool)
-	pipelinesConfig := &PipelinesEnabled{Enabled: pipelinesEnabled}
-
-	bytedata, err = json.Marshal(pipelinesConfig)
-
-	if err != nil {
-		return err
-	}
-
-	_, err = client.Put(fmt.Sprintf("2.0/repositories/%s/%s/pipelines_config",
-		d.Get("owner").(string),
-		repoSlug), bytes.NewBuffer(bytedata))
+	if enabled, ok := d.GetOkExists("pipelines_enabled"); ok {
+		pipelinesConfig := &api.PipelinesEnabled{Enabled: enabled.(bool)}
 
-	if err != nil {
-		return err
+		err = client.Repositories.UpdatePipelinesConfig(ctx, d.Get("owner").(string), repoSlug, pipelinesConfig)
+		if err != nil {
+			return err
+		}
 	}
 
 	return resourceRepositoryRead(d, m)
@@ -253,72 +215,47 @@ func resourceRepositoryRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	client := m.(*Client)
-	repoReq, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/%s",
-		d.Get("owner").(string),
-		repoSlug,
-	))
-
-	if repoReq.StatusCode == 200 {
-
-		var repo Repository
-
-		body, readerr := ioutil.ReadAll(repoReq.Body)
-		if readerr != nil {
-			return readerr
-		}
-
-		decodeerr := json.Unmarshal(body, &repo)
-		if decodeerr != nil {
-			return decodeerr
-		}
-
-		d.Set("scm", repo.SCM)
-		d.Set("is_private", repo.IsPrivate)
-		d.Set("has_wiki", repo.HasWiki)
-		d.Set("has_issues", repo.HasIssues)
-		d.Set("name", repo.Name)
-		if repo.Slug != "" && repo.Name != repo.Slug {
-			d.Set("slug", repo.Slug)
-		}
-		d.Set("language", repo.Language)
-		d.Set("fork_policy", repo.ForkPolicy)
-		d.Set("website", repo.Website)
-		d.Set("description", repo.Description)
-		d.Set("project_key", repo.Project.Key)
-
-		for _, cloneURL := range repo.Links.Clone {
-			if cloneURL.Name == "https" {
-				d.Set("clone_https", cloneURL.Href)
-			} else {
-				d.Set("clone_ssh", cloneURL.Href)
-			}
-		}
-		pipelinesConfigReq, err := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/pipelines_config",
-			d.Get("owner").(string),
-			repoSlug))
-
-		if err != nil {
-			return err
-		}
-
-		if pipelinesConfigReq.StatusCode == 200 {
-			var pipelinesConfig PipelinesEnabled
-
-			body, readerr := ioutil.ReadAll(pipelinesConfigReq.Body)
-			if readerr != nil {
-				return readerr
-			}
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+	repo, err := client.Repositories.Get(ctx, d.Get("owner").(string), repoSlug)
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
 
-			decodeerr := json.Unmarshal(body, &pipelinesConfig)
-			if decodeerr != nil {
-				return decodeerr
-			}
+	if err != nil {
+		return err
+	}
 
-			d.Set("pipelines_enabled", pipelinesConfig.Enabled)
+	d.Set("scm", repo.SCM)
+	d.Set("is_private", repo.IsPrivate)
+	d.Set("has_wiki", repo.HasWiki)
+	d.Set("has_issues", repo.HasIssues)
+	d.Set("name", repo.Name)
+	if repo.Slug != "" && repo.Name != repo.Slug {
+		d.Set("slug", repo.Slug)
+	}
+	d.Set("language", repo.Language)
+	d.Set("fork_policy", repo.ForkPolicy)
+	d.Set("website", repo.Website)
+	d.Set("description", repo.Description)
+	d.Set("project_key", repo.Project.Key)
+
+	for _, cloneURL := range repo.Links.Clone {
+		if cloneURL.Name == "https" {
+			d.Set("clone_https", cloneURL.Href)
+		} else {
+			d.Set("clone_ssh", cloneURL.Href)
 		}
+	}
 
+	pipelinesConfig, err := client.Repositories.GetPipelinesConfig(ctx, d.Get("owner").(string), repoSlug)
+	if err != nil {
+		return err
 	}
 
+	d.Set("pipelines_enabled", pipelinesConfig.Enabled)
+
 	return nil
 }
 
@@ -331,10 +268,8 @@ func resourceRepositoryDelete(d *schema.ResourceData, m interface{}) error {
 	}
 
 	client := m.(*Client)
-	_, err := client.Delete(fmt.Sprintf("2.0/repositories/%s/%s",
-		d.Get("owner").(string),
-		repoSlug,
-	))
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
 
-	return err
+	return client.Repositories.Delete(ctx, d.Get("owner").(string), repoSlug)
 }
@@ -0,0 +1,238 @@
+package bitbucket
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceRepositoryFork() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRepositoryForkCreate,
+		Read:   resourceRepositoryForkRead,
+		Update: resourceRepositoryForkUpdate,
+		Delete: resourceRepositoryForkDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"parent": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"slug": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"project_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"is_private": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"clone_ssh": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"clone_https": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceRepositoryForkCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+	parentOwner, parentSlug := client.splitRepository(d.Get("parent").(string))
+
+	parent, err := client.Repositories.Get(ctx, parentOwner, parentSlug)
+	if err != nil {
+		return err
+	}
+
+	fork := &api.RepositoryFork{
+		Name:        d.Get("name").(string),
+		Workspace:   &api.ForkWorkspace{Slug: owner},
+		Description: d.Get("description").(string),
+	}
+	if key, ok := d.GetOk("project_key"); ok {
+		fork.Project = &api.ForkProject{Key: key.(string)}
+	}
+	if private, ok := d.GetOkExists("is_private"); ok {
+		isPrivate := private.(bool)
+		fork.IsPrivate = &isPrivate
+	}
+
+	created, err := client.Repositories.Fork(ctx, parentOwner, parentSlug, fork)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", owner, created.Slug))
+
+	// The fork is usable once bitbucket has copied the main branch of the parent. A parent without
+	// commits has no main branch, its fork is usable right away.
+	_, err = waitForState(ctx, stateChange{
+		What:    "fork " + d.Id(),
+		Pending: []string{"forking"},
+		Target:  []string{"available"},
+		Refresh: func() (string, error) {
+			if _, err := client.Repositories.Get(ctx, owner, created.Slug); api.IsNotFound(err) {
+				return "forking", nil
+			} else if err != nil {
+				return "", err
+			}
+
+			if parent.MainBranch == nil || parent.MainBranch.Name == "" {
+				return "available", nil
+			}
+
+			if _, err := client.Refs.GetBranch(ctx, owner, created.Slug, parent.MainBranch.Name); api.IsNotFound(err) {
+				return "forking", nil
+			} else if err != nil {
+				return "", err
+			}
+			return "available", nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceRepositoryForkRead(d, m)
+}
+
+func resourceRepositoryForkRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/slug`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("slug", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	repo, err := client.Repositories.Get(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// The parent is kept as configured, it may leave out the workspace of the provider
+	if d.Get("parent").(string) == "" && repo.Parent != nil {
+		d.Set("parent", repo.Parent.FullName)
+	}
+	d.Set("name", repo.Name)
+	d.Set("description", repo.Description)
+	d.Set("is_private", repo.IsPrivate)
+	d.Set("project_key", repo.Project.Key)
+	d.Set("uuid", repo.UUID)
+	for _, cloneURL := range repo.Links.Clone {
+		if cloneURL.Name == "https" {
+			d.Set("clone_https", cloneURL.Href)
+		} else {
+			d.Set("clone_ssh", cloneURL.Href)
+		}
+	}
+
+	return nil
+}
+
+func resourceRepositoryForkUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	owner, slug := d.Get("owner").(string), d.Get("slug").(string)
+
+	// Bitbucket resets the settings that are left out, the ones the fork doesn't manage are sent as they are
+	current, err := client.Repositories.Get(ctx, owner, slug)
+	if err != nil {
+		return err
+	}
+
+	repository := &api.Repository{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		IsPrivate:   d.Get("is_private").(bool),
+		HasWiki:     current.HasWiki,
+		HasIssues:   current.HasIssues,
+		Website:     current.Website,
+		Language:    current.Language,
+		ForkPolicy:  current.ForkPolicy,
+	}
+	repository.Project.Key = d.Get("project_key").(string)
+
+	updated, err := client.Repositories.Update(ctx, owner, slug, repository)
+	if err != nil {
+		return err
+	}
+
+	if updated.Slug != "" && updated.Slug != slug {
+		log.Printf("[DEBUG] Fork %s/%s was renamed to %s/%s", owner, slug, owner, updated.Slug)
+		d.SetId(fmt.Sprintf("%s/%s", owner, updated.Slug))
+	}
+
+	return resourceRepositoryForkRead(d, m)
+}
+
+func resourceRepositoryForkDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.Repositories.Delete(ctx, d.Get("owner").(string), d.Get("slug").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
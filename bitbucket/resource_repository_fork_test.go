@@ -0,0 +1,62 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketRepositoryFork_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketRepositoryForkConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-fork-test"
+		}
+		resource "bitbucket_repository_fork" "test_fork" {
+			parent = "${bitbucket_repository.test_repo.owner}/${bitbucket_repository.test_repo.name}"
+			owner = "%s"
+			name = "test-fork-for-fork-test"
+		}
+	`, testUser, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryForkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketRepositoryForkConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository_fork.test_fork", "id", testTeam+"/test-fork-for-fork-test"),
+					resource.TestCheckResourceAttr("bitbucket_repository_fork.test_fork", "is_private", "true"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketRepositoryForkConfig, `name = "test-fork-for-fork-test"`, `name = "test-fork-for-fork-test"
+			description = "Illusions of the team"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository_fork.test_fork", "description", "Illusions of the team"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_repository_fork.test_fork",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketRepositoryForkDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_repository_fork.test_fork"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_repository_fork.test_fork")
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+// repositoryPermissions are the permissions a group or user can have on a repository
+var repositoryPermissions = []string{
+	"read",
+	"write",
+	"admin",
+}
+
+func resourceRepositoryGroupPermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRepositoryGroupPermissionCreate,
+		Read:   resourceRepositoryGroupPermissionRead,
+		Update: resourceRepositoryGroupPermissionUpdate,
+		Delete: resourceRepositoryGroupPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(repositoryPermissions, false),
+			},
+		},
+	}
+}
+
+func resourceRepositoryGroupPermissionCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	err = client.RepositoryPermissions.UpdateGroup(ctx, owner, d.Get("repository").(string), d.Get("group").(string), d.Get("permission").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", owner, d.Get("repository").(string), d.Get("group").(string)))
+	return resourceRepositoryGroupPermissionRead(d, m)
+}
+
+func resourceRepositoryGroupPermissionRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 3 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/repository/group`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("repository", idparts[1])
+	d.Set("group", idparts[2])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	permission, err := client.RepositoryPermissions.GetGroup(ctx, idparts[0], idparts[1], idparts[2])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("permission", permission.Permission)
+
+	return nil
+}
+
+func resourceRepositoryGroupPermissionUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	err := client.RepositoryPermissions.UpdateGroup(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Get("group").(string), d.Get("permission").(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceRepositoryGroupPermissionRead(d, m)
+}
+
+func resourceRepositoryGroupPermissionDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.RepositoryPermissions.DeleteGroup(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Get("group").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
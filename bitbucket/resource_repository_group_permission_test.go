@@ -0,0 +1,64 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketRepositoryGroupPermission_basic(t *testing.T) {
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketRepositoryGroupPermissionConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-group-permission-test"
+		}
+		resource "bitbucket_group" "test_group" {
+			workspace = "%s"
+			name = "test-group-for-permission-test"
+		}
+		resource "bitbucket_repository_group_permission" "test_permission" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			group = bitbucket_group.test_group.slug
+			permission = "read"
+		}
+	`, testTeam, testTeam, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryGroupPermissionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketRepositoryGroupPermissionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository_group_permission.test_permission", "permission", "read"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketRepositoryGroupPermissionConfig, `permission = "read"`, `permission = "admin"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository_group_permission.test_permission", "permission", "admin"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_repository_group_permission.test_permission",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketRepositoryGroupPermissionDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_repository_group_permission.test_permission"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_repository_group_permission.test_permission")
+	}
+	return nil
+}
@@ -1,22 +1,68 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+
+	"terraform-provider-bitbucket/bitbucket/api"
 )
 
 func TestAccBitbucketRepository_basic(t *testing.T) {
-	var repo Repository
+	var repo api.Repository
+
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketRepositoryConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-repository-test"
+		}
+	`, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketRepositoryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketRepositoryExists("bitbucket_repository.test_repo", &repo),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBitbucketRepository_update(t *testing.T) {
+	var repo api.Repository
 
 	testUser := os.Getenv("BITBUCKET_USERNAME")
 	testAccBitbucketRepositoryConfig := fmt.Sprintf(`
 		resource "bitbucket_repository" "test_repo" {
 			owner = "%s"
 			name = "test-repo-for-repository-test"
+			description = "Illusions"
+			has_issues = true
+			has_wiki = true
+			language = "go"
+			website = "https://example.com"
+			fork_policy = "no_public_forks"
+		}
+	`, testUser)
+
+	testAccBitbucketRepositoryConfigUpdated := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-repository-test-renamed"
+			has_issues = false
+			has_wiki = false
+			fork_policy = "no_forks"
 		}
 	`, testUser)
 
@@ -29,6 +75,55 @@ func TestAccBitbucketRepository_basic(t *testing.T) {
 				Config: testAccBitbucketRepositoryConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckBitbucketRepositoryExists("bitbucket_repository.test_repo", &repo),
+					resource.TestCheckResourceAttr("bitbucket_repository.test_repo", "has_issues", "true"),
+					resource.TestCheckResourceAttr("bitbucket_repository.test_repo", "description", "Illusions"),
+				),
+			},
+			{
+				Config: testAccBitbucketRepositoryConfigUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketRepositoryExists("bitbucket_repository.test_repo", &repo),
+					resource.TestCheckResourceAttr("bitbucket_repository.test_repo", "id", testUser+"/test-repo-for-repository-test-renamed"),
+					resource.TestCheckResourceAttr("bitbucket_repository.test_repo", "has_issues", "false"),
+					resource.TestCheckResourceAttr("bitbucket_repository.test_repo", "has_wiki", "false"),
+					resource.TestCheckResourceAttr("bitbucket_repository.test_repo", "description", ""),
+					resource.TestCheckResourceAttr("bitbucket_repository.test_repo", "website", ""),
+					resource.TestCheckResourceAttr("bitbucket_repository.test_repo", "fork_policy", "no_forks"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBitbucketRepository_pipelinesEnabled(t *testing.T) {
+	var repo api.Repository
+
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketRepositoryConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-repository-test"
+		}
+	`, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Replace(testAccBitbucketRepositoryConfig, `name = "test-repo-for-repository-test"`, `name = "test-repo-for-repository-test"
+			pipelines_enabled = true`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBitbucketRepositoryExists("bitbucket_repository.test_repo", &repo),
+					resource.TestCheckResourceAttr("bitbucket_repository.test_repo", "pipelines_enabled", "true"),
+				),
+			},
+			{
+				// Pipelines are no longer turned off when pipelines_enabled is removed from the config
+				Config: testAccBitbucketRepositoryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository.test_repo", "pipelines_enabled", "true"),
 				),
 			},
 		},
@@ -36,7 +131,7 @@ func TestAccBitbucketRepository_basic(t *testing.T) {
 }
 
 func TestAccBitbucketRepository_camelcase(t *testing.T) {
-	var repo Repository
+	var repo api.Repository
 
 	testUser := os.Getenv("BITBUCKET_USERNAME")
 	testAccBitbucketRepositoryConfig := fmt.Sprintf(`
@@ -69,7 +164,7 @@ func testAccCheckBitbucketRepositoryDestroy(s *terraform.State) error {
 		return fmt.Errorf("Not found %s", "bitbucket_repository.test_repo")
 	}
 
-	response, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["name"]))
+	response, _ := client.Get(context.Background(), fmt.Sprintf("2.0/repositories/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["name"]))
 
 	if response.StatusCode != 404 {
 		return fmt.Errorf("Repository still exists")
@@ -78,7 +173,7 @@ func testAccCheckBitbucketRepositoryDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketRepositoryExists(n string, repository *Repository) resource.TestCheckFunc {
+func testAccCheckBitbucketRepositoryExists(n string, repository *api.Repository) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -0,0 +1,125 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceRepositoryUserPermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRepositoryUserPermissionCreate,
+		Read:   resourceRepositoryUserPermissionRead,
+		Update: resourceRepositoryUserPermissionUpdate,
+		Delete: resourceRepositoryUserPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(repositoryPermissions, false),
+			},
+		},
+	}
+}
+
+func resourceRepositoryUserPermissionCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+
+	err = client.RepositoryPermissions.UpdateUser(ctx, owner, d.Get("repository").(string), d.Get("user").(string), d.Get("permission").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", owner, d.Get("repository").(string), d.Get("user").(string)))
+	return resourceRepositoryUserPermissionRead(d, m)
+}
+
+func resourceRepositoryUserPermissionRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 3 {
+		return fmt.Errorf("Incorrect ID format, should match `owner/repository/user`")
+	}
+	d.Set("owner", idparts[0])
+	d.Set("repository", idparts[1])
+	d.Set("user", idparts[2])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	permission, err := client.RepositoryPermissions.GetUser(ctx, idparts[0], idparts[1], idparts[2])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("permission", permission.Permission)
+
+	return nil
+}
+
+func resourceRepositoryUserPermissionUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	err := client.RepositoryPermissions.UpdateUser(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Get("user").(string), d.Get("permission").(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceRepositoryUserPermissionRead(d, m)
+}
+
+func resourceRepositoryUserPermissionDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.RepositoryPermissions.DeleteUser(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Get("user").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketRepositoryUserPermission_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketRepositoryUserPermissionConfig := fmt.Sprintf(`
+		data "bitbucket_user" "collaborator" {
+			username = "%s"
+		}
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-user-permission-test"
+		}
+		resource "bitbucket_repository_user_permission" "test_permission" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			user = data.bitbucket_user.collaborator.uuid
+			permission = "read"
+		}
+	`, testUser, testTeam, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryUserPermissionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketRepositoryUserPermissionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository_user_permission.test_permission", "permission", "read"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketRepositoryUserPermissionConfig, `permission = "read"`, `permission = "write"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository_user_permission.test_permission", "permission", "write"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_repository_user_permission.test_permission",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketRepositoryUserPermissionDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_repository_user_permission.test_permission"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_repository_user_permission.test_permission")
+	}
+	return nil
+}
@@ -1,23 +1,14 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
-)
 
-// RepositoryVariable structure for handling key info
-type RepositoryVariable struct {
-	Key     string `json:"key"`
-	Value   string `json:"value"`
-	UUID    string `json:"uuid,omitempty"`
-	Secured bool   `json:"secured"`
-}
+	"terraform-provider-bitbucket/bitbucket/api"
+)
 
 func resourceRepositoryVariable() *schema.Resource {
 	return &schema.Resource{
@@ -25,6 +16,15 @@ func resourceRepositoryVariable() *schema.Resource {
 		Update: resourceRepositoryVariableUpdate,
 		Read:   resourceRepositoryVariableRead,
 		Delete: resourceRepositoryVariableDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceRepositoryVariableImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"uuid": {
@@ -32,12 +32,14 @@ func resourceRepositoryVariable() *schema.Resource {
 				Computed: true,
 			},
 			"key": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateVariableKey,
 			},
 			"value": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
 			},
 			"secured": {
 				Type:     schema.TypeBool,
@@ -47,13 +49,30 @@ func resourceRepositoryVariable() *schema.Resource {
 			"repository": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
+			},
+			"force_update": {
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 		},
 	}
 }
 
-func newRepositoryVariableFromResource(d *schema.ResourceData) *RepositoryVariable {
-	dk := &RepositoryVariable{
+// variableKeyPattern is what bitbucket accepts as the key of a pipelines variable
+var variableKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateVariableKey checks the key of a variable only has letters, digits and underscores and does not
+// start with a digit, bitbucket rejects anything else with a 400 on apply.
+func validateVariableKey(v interface{}, k string) (ws []string, errors []error) {
+	if !variableKeyPattern.MatchString(v.(string)) {
+		errors = append(errors, fmt.Errorf("%q may only contain letters, digits and underscores and must not start with a digit, got %q", k, v))
+	}
+	return
+}
+
+func newRepositoryVariableFromResource(d *schema.ResourceData) *api.RepositoryVariable {
+	dk := &api.RepositoryVariable{
 		Key:     d.Get("key").(string),
 		Value:   d.Get("value").(string),
 		Secured: d.Get("secured").(bool),
@@ -64,31 +83,16 @@ func newRepositoryVariableFromResource(d *schema.ResourceData) *RepositoryVariab
 func resourceRepositoryVariableCreate(d *schema.ResourceData, m interface{}) error {
 
 	client := m.(*Client)
-	rvcr := newRepositoryVariableFromResource(d)
-	bytedata, err := json.Marshal(rvcr)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
 
-	if err != nil {
-		return err
-	}
-	req, err := client.Post(fmt.Sprintf("2.0/repositories/%s/pipelines_config/variables/",
-		d.Get("repository").(string),
-	), bytes.NewBuffer(bytedata))
+	owner, slug := client.splitRepository(d.Get("repository").(string))
 
+	rv, err := client.RepositoryVariables.Create(ctx, owner, slug, newRepositoryVariableFromResource(d))
 	if err != nil {
 		return err
 	}
 
-	var rv RepositoryVariable
-
-	body, readerr := ioutil.ReadAll(req.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	decodeerr := json.Unmarshal(body, &rv)
-	if decodeerr != nil {
-		return decodeerr
-	}
 	d.Set("uuid", rv.UUID)
 	d.SetId(rv.Key)
 
@@ -98,68 +102,90 @@ func resourceRepositoryVariableCreate(d *schema.ResourceData, m interface{}) err
 func resourceRepositoryVariableRead(d *schema.ResourceData, m interface{}) error {
 
 	client := m.(*Client)
-	rvReq, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/pipelines_config/variables/%s",
-		d.Get("repository").(string),
-		d.Get("uuid").(string),
-	))
-
-	log.Printf("ID: %s", url.PathEscape(d.Id()))
-
-	if rvReq.StatusCode == 200 {
-		var rv RepositoryVariable
-		body, readerr := ioutil.ReadAll(rvReq.Body)
-		if readerr != nil {
-			return readerr
-		}
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
 
-		decodeerr := json.Unmarshal(body, &rv)
-		if decodeerr != nil {
-			return decodeerr
-		}
+	owner, slug := client.splitRepository(d.Get("repository").(string))
 
-		d.Set("uuid", rv.UUID)
-		d.Set("key", rv.Key)
-		d.Set("value", rv.Value)
-		d.Set("secured", rv.Secured)
-	}
-
-	if rvReq.StatusCode == 404 {
+	rv, err := client.RepositoryVariables.Get(ctx, owner, slug, d.Get("uuid").(string))
+	if api.IsNotFound(err) {
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return err
+	}
+
+	d.Set("uuid", rv.UUID)
+	d.Set("key", rv.Key)
+	d.Set("secured", rv.Secured)
+
+	// Bitbucket never returns the value of a secured variable, keep the one that was sent.
+	if !rv.Secured {
+		d.Set("value", rv.Value)
+	}
 	return nil
 }
 
 func resourceRepositoryVariableUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	rvcr := newRepositoryVariableFromResource(d)
-	bytedata, err := json.Marshal(rvcr)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
 
-	if err != nil {
-		return err
-	}
-	req, err := client.Put(fmt.Sprintf("2.0/repositories/%s/pipelines_config/variables/%s",
-		d.Get("repository").(string),
-		d.Get("uuid").(string),
-	), bytes.NewBuffer(bytedata))
+	owner, slug := client.splitRepository(d.Get("repository").(string))
 
+	_, err := client.RepositoryVariables.Update(ctx, owner, slug, d.Get("uuid").(string), newRepositoryVariableFromResource(d))
 	if err != nil {
 		return err
 	}
 
-	if req.StatusCode != 200 {
-		return nil
-	}
-
 	return resourceRepositoryVariableRead(d, m)
 }
 
 func resourceRepositoryVariableDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	_, err := client.Delete(fmt.Sprintf(fmt.Sprintf("2.0/repositories/%s/pipelines_config/variables/%s",
-		d.Get("repository").(string),
-		d.Get("uuid").(string),
-	)))
-	return err
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	owner, slug := client.splitRepository(d.Get("repository").(string))
+
+	return client.RepositoryVariables.Delete(ctx, owner, slug, d.Get("uuid").(string))
+}
+
+// variableQuery matches a pipelines variable by its uuid or its key
+func variableQuery(variable string) api.Query {
+	return api.Or(api.Eq("uuid", withBraces(variable)), api.Eq("key", variable))
+}
+
+// resourceRepositoryVariableImport adopts a variable by an id like `workspace/repo_slug/variable_uuid`,
+// the variable can also be given by its key.
+func resourceRepositoryVariableImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Expected an import id like workspace/repo_slug/variable_uuid or workspace/repo_slug/key, got %q", d.Id())
+	}
+
+	owner, slug, variable := parts[0], parts[1], parts[2]
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	// Only the variable asked for is listed, matched again below by its exact uuid or key
+	variables, err := client.RepositoryVariables.Search(ctx, owner, slug, variableQuery(variable))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rv := range variables {
+		if rv.UUID == withBraces(variable) || rv.Key == variable {
+			d.Set("repository", fmt.Sprintf("%s/%s", owner, slug))
+			d.Set("uuid", rv.UUID)
+			d.SetId(rv.Key)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s/%s has no pipelines variable with the uuid or key %q", owner, slug, variable)
 }
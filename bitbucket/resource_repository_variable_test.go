@@ -37,10 +37,27 @@ func TestAccBitbucketRepositoryVariable_basic(t *testing.T) {
 					testAccCheckBitbucketRepositoryVariableExists("bitbucket_repository_variable.testvar", "test", "test"),
 				),
 			},
+			{
+				ResourceName:      "bitbucket_repository_variable.testvar",
+				ImportState:       true,
+				ImportStateIdFunc: testAccBitbucketRepositoryVariableImportID("bitbucket_repository_variable.testvar"),
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
 
+func testAccBitbucketRepositoryVariableImportID(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found %s", n)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["repository"], rs.Primary.Attributes["key"]), nil
+	}
+}
+
 func testAccCheckBitbucketRepositoryVariableDestroy(s *terraform.State) error {
 	_, ok := s.RootModule().Resources["bitbucket_repository_variable.testvar"]
 	if !ok {
@@ -68,3 +85,21 @@ func testAccCheckBitbucketRepositoryVariableExists(n, key, value string) resourc
 		return nil
 	}
 }
+
+func TestValidateVariableKey(t *testing.T) {
+	cases := map[string]bool{
+		"COUNTRY":      true,
+		"_private":     true,
+		"API_KEY_2":    true,
+		"2FA":          false,
+		"API-KEY":      false,
+		"COUNTRY CODE": false,
+		"":             false,
+	}
+
+	for key, valid := range cases {
+		if _, errors := validateVariableKey(key, "key"); (len(errors) == 0) != valid {
+			t.Fatalf("expected %q to be valid: %t, got %v", key, valid, errors)
+		}
+	}
+}
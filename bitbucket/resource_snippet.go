@@ -0,0 +1,178 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceSnippet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSnippetCreate,
+		Read:   resourceSnippetRead,
+		Update: resourceSnippetUpdate,
+		Delete: resourceSnippetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"is_private": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"file": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"content": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"snippet_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// snippetFilesByName turns the file blocks into their content keyed by their name
+func snippetFilesByName(set *schema.Set) map[string]string {
+	files := make(map[string]string, set.Len())
+
+	for _, v := range set.List() {
+		file := v.(map[string]interface{})
+		files[file["name"].(string)] = file["content"].(string)
+	}
+
+	return files
+}
+
+func resourceSnippetCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	workspace, err := client.workspaceIn(d, "workspace")
+	if err != nil {
+		return err
+	}
+
+	snippet, err := client.Snippets.Create(ctx, workspace, d.Get("title").(string), d.Get("is_private").(bool), snippetFilesByName(d.Get("file").(*schema.Set)))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", workspace, snippet.ID))
+
+	return resourceSnippetRead(d, m)
+}
+
+func resourceSnippetRead(d *schema.ResourceData, m interface{}) error {
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return fmt.Errorf("Incorrect ID format, should match `workspace/id`")
+	}
+	d.Set("workspace", idparts[0])
+	d.Set("snippet_id", idparts[1])
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	snippet, err := client.Snippets.Get(ctx, idparts[0], idparts[1])
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("title", snippet.Title)
+	d.Set("is_private", snippet.IsPrivate)
+
+	// Every file of the snippet is managed, the ones added outside of terraform show up as a change
+	files := make([]interface{}, 0, len(snippet.Files))
+	for name := range snippet.Files {
+		content, err := client.Snippets.GetFile(ctx, idparts[0], idparts[1], name)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, map[string]interface{}{
+			"name":    name,
+			"content": content,
+		})
+	}
+	d.Set("file", files)
+
+	return nil
+}
+
+func resourceSnippetUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	files := snippetFilesByName(d.Get("file").(*schema.Set))
+
+	// Files that are not configured anymore are removed by the same update
+	var deleted []string
+	old, _ := d.GetChange("file")
+	for name := range snippetFilesByName(old.(*schema.Set)) {
+		if _, ok := files[name]; !ok {
+			deleted = append(deleted, name)
+		}
+	}
+
+	_, err := client.Snippets.Update(ctx, d.Get("workspace").(string), d.Get("snippet_id").(string), d.Get("title").(string), d.Get("is_private").(bool), files, deleted)
+	if err != nil {
+		return err
+	}
+
+	return resourceSnippetRead(d, m)
+}
+
+func resourceSnippetDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.Snippets.Delete(ctx, d.Get("workspace").(string), d.Get("snippet_id").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,59 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketSnippet_basic(t *testing.T) {
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketSnippetConfig := fmt.Sprintf(`
+		resource "bitbucket_snippet" "test_snippet" {
+			workspace = "%s"
+			title = "test-snippet-for-snippet-test"
+			file {
+				name = "trick.sh"
+				content = "echo now you see me"
+			}
+		}
+	`, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketSnippetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketSnippetConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_snippet.test_snippet", "is_private", "true"),
+					resource.TestCheckResourceAttr("bitbucket_snippet.test_snippet", "file.#", "1"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketSnippetConfig, `name = "trick.sh"`, `name = "illusion.sh"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_snippet.test_snippet", "file.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_snippet.test_snippet",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketSnippetDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_snippet.test_snippet"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_snippet.test_snippet")
+	}
+	return nil
+}
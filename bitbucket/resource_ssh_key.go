@@ -0,0 +1,152 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceSSHKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSSHKeyCreate,
+		Read:   resourceSSHKeyRead,
+		Update: resourceSSHKeyUpdate,
+		Delete: resourceSSHKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSSHKeyImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"key": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressPublicKeyComment,
+			},
+			"label": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func newSSHKeyFromResource(d *schema.ResourceData) *api.SSHKey {
+	return &api.SSHKey{
+		Key:   d.Get("key").(string),
+		Label: d.Get("label").(string),
+	}
+}
+
+func resourceSSHKeyCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	// Without a user the key goes to the account the provider is authenticated as
+	user := d.Get("user").(string)
+	if user == "" {
+		current, err := client.Users.Current(ctx)
+		if err != nil {
+			return err
+		}
+		user = current.UUID
+		d.Set("user", user)
+	}
+
+	key, err := client.SSHKeys.Create(ctx, user, newSSHKeyFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(key.UUID)
+
+	return resourceSSHKeyRead(d, m)
+}
+
+func resourceSSHKeyRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	key, err := client.SSHKeys.Get(ctx, d.Get("user").(string), d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Keep the key as configured when only the comment bitbucket dropped differs
+	if publicKeyWithoutComment(key.Key) != publicKeyWithoutComment(d.Get("key").(string)) {
+		d.Set("key", key.Key)
+	}
+	d.Set("uuid", key.UUID)
+	d.Set("label", key.Label)
+	d.Set("comment", key.Comment)
+
+	return nil
+}
+
+func resourceSSHKeyUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	_, err := client.SSHKeys.Update(ctx, d.Get("user").(string), d.Id(), newSSHKeyFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceSSHKeyRead(d, m)
+}
+
+func resourceSSHKeyDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.SSHKeys.Delete(ctx, d.Get("user").(string), d.Id())
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// resourceSSHKeyImport adopts an ssh key by an id like `user_uuid/key_uuid`
+func resourceSSHKeyImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Expected an import id like {user-uuid}/{key-uuid}, got %q", d.Id())
+	}
+
+	d.Set("user", parts[0])
+	d.SetId(withBraces(parts[1]))
+
+	return []*schema.ResourceData{d}, nil
+}
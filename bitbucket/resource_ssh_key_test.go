@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketSSHKey_basic(t *testing.T) {
+	testAccBitbucketSSHKeyConfig := fmt.Sprintf(`
+		resource "bitbucket_ssh_key" "test_key" {
+			key = "%s mirror@example.com"
+			label = "mirror"
+		}
+	`, testAccPublicKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketSSHKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketSSHKeyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucket_ssh_key.test_key", "user"),
+					resource.TestCheckResourceAttr("bitbucket_ssh_key.test_key", "comment", "mirror@example.com"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketSSHKeyConfig, `label = "mirror"`, `label = "rotated"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_ssh_key.test_key", "label", "rotated"),
+				),
+			},
+			{
+				ResourceName:            "bitbucket_ssh_key.test_key",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccBitbucketSSHKeyImportID("bitbucket_ssh_key.test_key"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
+		},
+	})
+}
+
+func testAccBitbucketSSHKeyImportID(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found %s", n)
+		}
+		return rs.Primary.Attributes["user"] + "/" + rs.Primary.ID, nil
+	}
+}
+
+func testAccCheckBitbucketSSHKeyDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_ssh_key.test_key"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_ssh_key.test_key")
+	}
+	return nil
+}
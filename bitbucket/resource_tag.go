@@ -0,0 +1,129 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceTag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTagCreate,
+		Read:   resourceTagRead,
+		Delete: resourceTagDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"target": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"message": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTagCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	owner, err := client.ownerFor(d)
+	if err != nil {
+		return err
+	}
+	repository := d.Get("repository").(string)
+
+	tag, err := client.Refs.CreateTag(ctx, owner, repository, d.Get("name").(string), d.Get("target").(string), d.Get("message").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", owner, repository, tag.Name))
+
+	return resourceTagRead(d, m)
+}
+
+func resourceTagRead(d *schema.ResourceData, m interface{}) error {
+	owner, repository, name, err := splitRefId(d.Id())
+	if err != nil {
+		return err
+	}
+	d.Set("owner", owner)
+	d.Set("repository", repository)
+	d.Set("name", name)
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	tag, err := client.Refs.GetTag(ctx, owner, repository, name)
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// The target may name a branch, the tag stays at the commit the branch was at. An imported tag takes
+	// its commit as its target.
+	if tag.Target != nil {
+		d.Set("hash", tag.Target.Hash)
+		if d.Get("target").(string) == "" {
+			d.Set("target", tag.Target.Hash)
+		}
+	}
+	// git ends the message of an annotated tag with a newline
+	d.Set("message", strings.TrimSuffix(tag.Message, "\n"))
+
+	return nil
+}
+
+func resourceTagDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.Refs.DeleteTag(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Get("name").(string))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
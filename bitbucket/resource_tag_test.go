@@ -0,0 +1,57 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketTag_basic(t *testing.T) {
+	testUser := os.Getenv("BITBUCKET_USERNAME")
+	testAccBitbucketTagConfig := fmt.Sprintf(`
+		resource "bitbucket_repository" "test_repo" {
+			owner = "%s"
+			name = "test-repo-for-tag-test"
+		}
+		resource "bitbucket_tag" "test_tag" {
+			owner = "%s"
+			repository = bitbucket_repository.test_repo.name
+			name = "v1.0"
+			target = "master"
+			message = "Release 1.0"
+		}
+	`, testUser, testUser)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketTagDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketTagConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_tag.test_tag", "id", testUser+"/test-repo-for-tag-test/v1.0"),
+					resource.TestCheckResourceAttrSet("bitbucket_tag.test_tag", "hash"),
+					resource.TestCheckResourceAttr("bitbucket_tag.test_tag", "message", "Release 1.0"),
+				),
+			},
+			{
+				ResourceName:            "bitbucket_tag.test_tag",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"target"},
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketTagDestroy(s *terraform.State) error {
+	_, ok := s.RootModule().Resources["bitbucket_tag.test_tag"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_tag.test_tag")
+	}
+	return nil
+}
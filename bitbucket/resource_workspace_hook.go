@@ -0,0 +1,149 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceWorkspaceHook() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceWorkspaceHookCreate,
+		Read:   resourceWorkspaceHookRead,
+		Update: resourceWorkspaceHookUpdate,
+		Delete: resourceWorkspaceHookDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceWorkspaceHookImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"events": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"skip_cert_verification": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceWorkspaceHookCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	workspace, err := client.workspaceIn(d, "workspace")
+	if err != nil {
+		return err
+	}
+
+	hook, err := client.Hooks.CreateForWorkspace(ctx, workspace, createHook(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(hook.UUID)
+
+	return resourceWorkspaceHookRead(d, m)
+}
+
+func resourceWorkspaceHookRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	hook, err := client.Hooks.GetForWorkspace(ctx, d.Get("workspace").(string), d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("uuid", hook.UUID)
+	d.Set("description", hook.Description)
+	d.Set("active", hook.Active)
+	d.Set("url", hook.URL)
+	d.Set("skip_cert_verification", hook.SkipCertVerification)
+	d.Set("events", hook.Events)
+
+	return nil
+}
+
+func resourceWorkspaceHookUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	_, err := client.Hooks.UpdateForWorkspace(ctx, d.Get("workspace").(string), d.Id(), createHook(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceWorkspaceHookRead(d, m)
+}
+
+func resourceWorkspaceHookDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	err := client.Hooks.DeleteForWorkspace(ctx, d.Get("workspace").(string), d.Id())
+	if api.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// resourceWorkspaceHookImport adopts a hook by an id like `workspace/hook_uuid`
+func resourceWorkspaceHookImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Expected an import id like workspace/hook_uuid, got %q", d.Id())
+	}
+
+	d.Set("workspace", parts[0])
+	d.Set("uuid", withBraces(parts[1]))
+	d.SetId(withBraces(parts[1]))
+
+	return []*schema.ResourceData{d}, nil
+}
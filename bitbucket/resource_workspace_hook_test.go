@@ -0,0 +1,71 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBitbucketWorkspaceHook_basic(t *testing.T) {
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketWorkspaceHookConfig := fmt.Sprintf(`
+		resource "bitbucket_workspace_hook" "test_hook" {
+			workspace = "%s"
+			description = "Test workspace hook for terraform"
+			url = "https://httpbin.org"
+			events = [
+				"repo:push",
+			]
+		}
+	`, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketWorkspaceHookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketWorkspaceHookConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_workspace_hook.test_hook", "active", "true"),
+					resource.TestCheckResourceAttrSet("bitbucket_workspace_hook.test_hook", "uuid"),
+				),
+			},
+			{
+				Config: strings.Replace(testAccBitbucketWorkspaceHookConfig, `url = "https://httpbin.org"`, `url = "https://httpbin.org"
+			active = false`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_workspace_hook.test_hook", "active", "false"),
+				),
+			},
+			{
+				ResourceName:        "bitbucket_workspace_hook.test_hook",
+				ImportState:         true,
+				ImportStateIdPrefix: testTeam + "/",
+				ImportStateVerify:   true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketWorkspaceHookDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	rs, ok := s.RootModule().Resources["bitbucket_workspace_hook.test_hook"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_workspace_hook.test_hook")
+	}
+
+	response, _ := client.Get(context.Background(), fmt.Sprintf("2.0/workspaces/%s/hooks/%s", rs.Primary.Attributes["workspace"], url.PathEscape(rs.Primary.Attributes["uuid"])))
+
+	if response.StatusCode != 404 {
+		return fmt.Errorf("Workspace hook still exists")
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func resourceWorkspaceVariable() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceWorkspaceVariableCreate,
+		Update: resourceWorkspaceVariableUpdate,
+		Read:   resourceWorkspaceVariableRead,
+		Delete: resourceWorkspaceVariableDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceWorkspaceVariableImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateVariableKey,
+			},
+			"value": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"secured": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"force_update": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func newWorkspaceVariableFromResource(d *schema.ResourceData) *api.WorkspaceVariable {
+	return &api.WorkspaceVariable{
+		Key:     d.Get("key").(string),
+		Value:   d.Get("value").(string),
+		Secured: d.Get("secured").(bool),
+	}
+}
+
+func resourceWorkspaceVariableCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutCreate)
+	defer cancel()
+
+	workspace, err := client.workspaceIn(d, "workspace")
+	if err != nil {
+		return err
+	}
+
+	wv, err := client.WorkspaceVariables.Create(ctx, workspace, newWorkspaceVariableFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	d.Set("uuid", wv.UUID)
+	d.SetId(wv.UUID)
+
+	return resourceWorkspaceVariableRead(d, m)
+}
+
+func resourceWorkspaceVariableRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	wv, err := client.WorkspaceVariables.Get(ctx, d.Get("workspace").(string), d.Id())
+	if api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("uuid", wv.UUID)
+	d.Set("key", wv.Key)
+	d.Set("secured", wv.Secured)
+
+	// Bitbucket never returns the value of a secured variable, keep the one that was sent.
+	if !wv.Secured {
+		d.Set("value", wv.Value)
+	}
+	return nil
+}
+
+func resourceWorkspaceVariableUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutUpdate)
+	defer cancel()
+
+	_, err := client.WorkspaceVariables.Update(ctx, d.Get("workspace").(string), d.Id(), newWorkspaceVariableFromResource(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceWorkspaceVariableRead(d, m)
+}
+
+func resourceWorkspaceVariableDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutDelete)
+	defer cancel()
+
+	return client.WorkspaceVariables.Delete(ctx, d.Get("workspace").(string), d.Id())
+}
+
+// resourceWorkspaceVariableImport adopts a variable by an id like `workspace/variable_uuid`, the variable
+// can also be given by its key.
+func resourceWorkspaceVariableImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Expected an import id like workspace/variable_uuid or workspace/key, got %q", d.Id())
+	}
+
+	workspace, variable := parts[0], parts[1]
+
+	client := m.(*Client)
+	ctx, cancel := client.contextFor(d, schema.TimeoutRead)
+	defer cancel()
+
+	// Only the variable asked for is listed, matched again below by its exact uuid or key
+	variables, err := client.WorkspaceVariables.Search(ctx, workspace, variableQuery(variable))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, wv := range variables {
+		if wv.UUID == withBraces(variable) || wv.Key == variable {
+			d.Set("workspace", workspace)
+			d.Set("uuid", wv.UUID)
+			d.SetId(wv.UUID)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Workspace %s has no pipelines variable with the uuid or key %q", workspace, variable)
+}
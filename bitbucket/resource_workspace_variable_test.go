@@ -0,0 +1,62 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"terraform-provider-bitbucket/bitbucket/api"
+)
+
+func TestAccBitbucketWorkspaceVariable_basic(t *testing.T) {
+
+	testTeam := os.Getenv("BITBUCKET_TEAM")
+	testAccBitbucketWorkspaceVariableConfig := fmt.Sprintf(`
+		resource "bitbucket_workspace_variable" "testvar" {
+			workspace = "%s"
+			key = "test"
+			value = "test"
+			secured = false
+		}
+	`, testTeam)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketWorkspaceVariableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketWorkspaceVariableConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_workspace_variable.testvar", "key", "test"),
+					resource.TestCheckResourceAttr("bitbucket_workspace_variable.testvar", "value", "test"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_workspace_variable.testvar",
+				ImportState:       true,
+				ImportStateId:     testTeam + "/test",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketWorkspaceVariableDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	rs, ok := s.RootModule().Resources["bitbucket_workspace_variable.testvar"]
+	if !ok {
+		return fmt.Errorf("Not found %s", "bitbucket_workspace_variable.testvar")
+	}
+
+	_, err := client.WorkspaceVariables.Get(context.Background(), rs.Primary.Attributes["workspace"], rs.Primary.ID)
+	if !api.IsNotFound(err) {
+		return fmt.Errorf("Workspace variable still exists")
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package bitbucket
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform/helper/pathorcontents"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// maxIdleConnsPerHost is how many connections to bitbucket are kept open for reuse, enough for the
+// default parallelism of terraform.
+const maxIdleConnsPerHost = 10
+
+// newTransport builds the transport all requests to bitbucket go through from the provider settings.
+// Without a proxy_url the usual HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.
+func newTransport(d *schema.ResourceData) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	// The default of two idle connections makes parallel requests open a new connection every time.
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	if n := d.Get("parallel_requests").(int); n > maxIdleConnsPerHost {
+		transport.MaxIdleConnsPerHost = n
+	}
+
+	if v := d.Get("proxy_url").(string); v != "" {
+		proxyURL, err := url.Parse(v)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("proxy_url must be an absolute url like http://proxy.example.com:3128")
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(d)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// newTLSConfig builds the tls settings, needed when bitbucket is reached through a TLS intercepting
+// proxy or a gateway with its own certificate authority.
+func newTLSConfig(d *schema.ResourceData) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+	}
+
+	if path := d.Get("ca_cert_file").(string); path != "" {
+		pem, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read ca_cert_file: %s", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca_cert_file %s does not contain any PEM encoded certificates", path)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	clientCert := d.Get("client_cert").(string)
+	clientKey := d.Get("client_key").(string)
+
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" || clientKey == "" {
+			return nil, fmt.Errorf("client_cert and client_key must be set together")
+		}
+
+		certPEM, _, err := pathorcontents.Read(clientCert)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read client_cert: %s", err)
+		}
+
+		keyPEM, _, err := pathorcontents.Read(clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read client_key: %s", err)
+		}
+
+		cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("Could not load the client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,56 @@
+package bitbucket
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func testProviderData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, raw)
+}
+
+func TestNewTransport_proxyURL(t *testing.T) {
+	transport, err := newTransport(testProviderData(t, map[string]interface{}{
+		"proxy_url": "http://proxy.example.com:3128",
+	}))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	req, _ := http.NewRequest("GET", "https://api.bitbucket.org/2.0/user", nil)
+	proxyURL, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if proxyURL == nil || proxyURL.Host != "proxy.example.com:3128" {
+		t.Fatalf("expected the request to go through the proxy, got %v", proxyURL)
+	}
+
+	if _, err := newTransport(testProviderData(t, map[string]interface{}{"proxy_url": "proxy"})); err == nil {
+		t.Fatalf("expected an error for a relative proxy_url")
+	}
+}
+
+func TestNewTransport_tls(t *testing.T) {
+	transport, err := newTransport(testProviderData(t, map[string]interface{}{
+		"insecure_skip_verify": true,
+	}))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatalf("expected insecure_skip_verify to be passed on")
+	}
+
+	if _, err := newTransport(testProviderData(t, map[string]interface{}{"ca_cert_file": "/does/not/exist.pem"})); err == nil {
+		t.Fatalf("expected an error for a missing ca_cert_file")
+	}
+
+	if _, err := newTransport(testProviderData(t, map[string]interface{}{"client_cert": "cert"})); err == nil {
+		t.Fatalf("expected an error when client_key is missing")
+	}
+}
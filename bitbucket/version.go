@@ -0,0 +1,5 @@
+package bitbucket
+
+// ProviderVersion is the version of the provider, set at build time with
+// -ldflags "-X terraform-provider-bitbucket/bitbucket.ProviderVersion=$(cat VERSION)"
+var ProviderVersion = "dev"
@@ -0,0 +1,86 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// stateChange describes an asynchronous operation that is done once Refresh reports one of the Target
+// states. Bitbucket reports pipeline runs, forks and the deletion of environments as done while they
+// are still in progress, resources block on them with waitForState.
+type stateChange struct {
+	// What is waited for, used in logs and errors
+	What string
+	// Pending states are expected on the way to a target state, any other state is an error
+	Pending []string
+	Target  []string
+	// Refresh returns the current state
+	Refresh func() (string, error)
+	// Timeout bounds the wait on top of the deadline of the context, zero leaves it to the context
+	Timeout time.Duration
+	// MinWait is the wait before the second check, it doubles for every further check up to MaxWait
+	MinWait time.Duration
+	MaxWait time.Duration
+}
+
+// waitForState checks the state of conf until it reaches a target state and returns that state.
+func waitForState(ctx context.Context, conf stateChange) (string, error) {
+	if conf.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, conf.Timeout)
+		defer cancel()
+	}
+
+	wait := conf.MinWait
+	if wait <= 0 {
+		wait = 250 * time.Millisecond
+	}
+	maxWait := conf.MaxWait
+	if maxWait <= 0 {
+		maxWait = 5 * time.Second
+	}
+
+	for {
+		state, err := conf.Refresh()
+		if err != nil {
+			return state, err
+		}
+
+		if containsState(conf.Target, state) {
+			return state, nil
+		}
+
+		if !containsState(conf.Pending, state) {
+			return state, fmt.Errorf("%s is %q, expected one of [%s]", conf.What, state, strings.Join(append(conf.Pending, conf.Target...), ", "))
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && !time.Now().Add(wait).Before(deadline) {
+			return state, fmt.Errorf("%s is still %q, gave up waiting for [%s]", conf.What, state, strings.Join(conf.Target, ", "))
+		}
+
+		log.Printf("[DEBUG] %s is %q, checking again in %s", conf.What, state, wait)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return state, ctx.Err()
+		case <-timer.C:
+		}
+
+		if wait *= 2; wait > maxWait {
+			wait = maxWait
+		}
+	}
+}
+
+func containsState(states []string, state string) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package bitbucket
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForState(t *testing.T) {
+	states := []string{"PENDING", "IN_PROGRESS", "COMPLETED"}
+
+	checks := 0
+	state, err := waitForState(context.Background(), stateChange{
+		What:    "pipeline",
+		Pending: []string{"PENDING", "IN_PROGRESS"},
+		Target:  []string{"COMPLETED"},
+		MinWait: time.Millisecond,
+		Refresh: func() (string, error) {
+			checks++
+			return states[checks-1], nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if state != "COMPLETED" || checks != 3 {
+		t.Fatalf("expected to wait for COMPLETED, got %q after %d checks", state, checks)
+	}
+}
+
+func TestWaitForState_unexpected(t *testing.T) {
+	_, err := waitForState(context.Background(), stateChange{
+		What:    "pipeline",
+		Pending: []string{"PENDING"},
+		Target:  []string{"COMPLETED"},
+		Refresh: func() (string, error) { return "FAILED", nil },
+	})
+
+	if err == nil || !strings.Contains(err.Error(), `pipeline is "FAILED"`) {
+		t.Fatalf("expected an error for an unexpected state, got %v", err)
+	}
+}
+
+func TestWaitForState_timeout(t *testing.T) {
+	_, err := waitForState(context.Background(), stateChange{
+		What:    "fork",
+		Pending: []string{"PENDING"},
+		Target:  []string{"COMPLETED"},
+		Timeout: 20 * time.Millisecond,
+		MinWait: time.Millisecond,
+		Refresh: func() (string, error) { return "PENDING", nil },
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "gave up waiting") {
+		t.Fatalf("expected to give up once the timeout passed, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// ownerFor returns the owner of the resource, falling back to the workspace set on the provider. The
+// owner is saved on the resource so the state always knows where it lives.
+func (c *Client) ownerFor(d *schema.ResourceData) (string, error) {
+	return c.workspaceIn(d, "owner")
+}
+
+// workspaceIn returns the workspace of the resource in its attribute key, falling back to the workspace
+// set on the provider the way ownerFor does.
+func (c *Client) workspaceIn(d *schema.ResourceData, key string) (string, error) {
+	if workspace := d.Get(key).(string); workspace != "" {
+		return workspace, nil
+	}
+
+	if c.Workspace == "" {
+		return "", fmt.Errorf("%s must be set on the resource or workspace on the provider", key)
+	}
+
+	d.Set(key, c.Workspace)
+	return c.Workspace, nil
+}
+
+// repositoryFor turns a repository given as just a slug into `workspace/slug` using the workspace
+// set on the provider. Repositories that already name their owner are returned as is.
+func (c *Client) repositoryFor(repository string) string {
+	if strings.Contains(repository, "/") || c.Workspace == "" {
+		return repository
+	}
+
+	return fmt.Sprintf("%s/%s", c.Workspace, repository)
+}
+
+// splitRepository returns the owner and slug of a repository given as `owner/slug` or as a slug in the
+// workspace set on the provider.
+func (c *Client) splitRepository(repository string) (string, string) {
+	parts := strings.SplitN(c.repositoryFor(repository), "/", 2)
+	if len(parts) != 2 {
+		return "", parts[0]
+	}
+
+	return parts[0], parts[1]
+}
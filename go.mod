@@ -1,5 +1,9 @@
 module terraform-provider-bitbucket
 
-require github.com/hashicorp/terraform v0.13.3
+require (
+	github.com/hashicorp/terraform v0.13.3
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
+	golang.org/x/oauth2 v0.0.0-20190604053449-0f29369cfe45
+)
 
 go 1.14